@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/magodo/tfpluginschema"
+	"github.com/magodo/tfpluginschema/diff"
+	"github.com/magodo/tfpluginschema/schema"
+)
+
+// exitCodeBreaking is the exit code of the breaking command when breaking changes are found.
+const exitCodeBreaking = 3
+
+func runBreaking(ctx context.Context, args []string) error {
+	var (
+		providerAddress string
+		all             bool
+		strict          bool
+		asJSON          bool
+	)
+	fs := flag.NewFlagSet("breaking", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), `Report the breaking changes between two provider schema files.
+
+Usage: tfpluginschema breaking [options] <old schema file> <new schema file>
+
+The schema files can be either dumped by "tfpluginschema dump", or by "terraform providers schema -json".
+The command exits with %d if there is any breaking change.
+
+Options:
+`, exitCodeBreaking)
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&providerAddress, "provider-address", "", "The provider source address to compare, required if the terraform providers schema files contain multiple providers")
+	fs.BoolVar(&all, "all", false, "Also report the safe changes")
+	fs.BoolVar(&strict, "strict", false, "Also treat the potentially breaking changes as breaking")
+	fs.BoolVar(&asJSON, "json", false, "Output the changes in JSON")
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("expect 2 arguments, got %d", fs.NArg())
+	}
+
+	oldSchema, err := readProviderSchemaFile(fs.Arg(0), providerAddress)
+	if err != nil {
+		return err
+	}
+	newSchema, err := readProviderSchemaFile(fs.Arg(1), providerAddress)
+	if err != nil {
+		return err
+	}
+
+	var changes []diff.ClassifiedChange
+	for _, change := range diff.ClassifyProviderSchema(oldSchema, newSchema) {
+		if all || change.Severity != diff.SeveritySafe {
+			changes = append(changes, change)
+		}
+	}
+
+	if asJSON {
+		if err := writeChangesJSON(changes); err != nil {
+			return err
+		}
+	} else {
+		for _, change := range changes {
+			fmt.Println(change)
+		}
+	}
+
+	threshold := diff.SeverityBreaking
+	if strict {
+		threshold = diff.SeverityPotentiallyBreaking
+	}
+	if diff.MaxSeverity(changes) >= threshold {
+		return exitError{code: exitCodeBreaking}
+	}
+	return nil
+}
+
+func writeChangesJSON(changes []diff.ClassifiedChange) error {
+	type jsonChange struct {
+		Severity   string   `json:"severity"`
+		Reason     string   `json:"reason"`
+		Kind       string   `json:"kind"`
+		SchemaKind string   `json:"schema_kind"`
+		SchemaName string   `json:"schema_name,omitempty"`
+		Element    string   `json:"element"`
+		Path       []string `json:"path,omitempty"`
+		Property   string   `json:"property,omitempty"`
+		Message    string   `json:"message"`
+	}
+	out := []jsonChange{}
+	for _, change := range changes {
+		out = append(out, jsonChange{
+			Severity:   change.Severity.String(),
+			Reason:     change.Reason,
+			Kind:       change.Kind.String(),
+			SchemaKind: change.SchemaKind.String(),
+			SchemaName: change.SchemaName,
+			Element:    change.Element.String(),
+			Path:       change.Path,
+			Property:   change.Property,
+			Message:    change.Change.String(),
+		})
+	}
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(append(b, '\n'))
+	return err
+}
+
+// readProviderSchemaFile reads the provider schema from a file, which is either in the tfpluginschema format,
+// or in the `terraform providers schema -json` format.
+func readProviderSchemaFile(path, providerAddress string) (*schema.ProviderSchema, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var probe struct {
+		FormatVersion string `json:"format_version"`
+	}
+	if err := json.Unmarshal(b, &probe); err != nil {
+		return nil, fmt.Errorf("unmarshalling %s: %v", path, err)
+	}
+	if probe.FormatVersion == "" {
+		var sch schema.ProviderSchema
+		if err := json.Unmarshal(b, &sch); err != nil {
+			return nil, fmt.Errorf("unmarshalling %s: %v", path, err)
+		}
+		return &sch, nil
+	}
+
+	schemas, err := tfpluginschema.FromTerraformProvidersSchemaJSON(b)
+	if err != nil {
+		return nil, fmt.Errorf("converting %s: %v", path, err)
+	}
+	if providerAddress != "" {
+		sch, ok := schemas[providerAddress]
+		if !ok {
+			return nil, fmt.Errorf("provider %s not found in %s", providerAddress, path)
+		}
+		return sch, nil
+	}
+	if len(schemas) != 1 {
+		var addrs []string
+		for addr := range schemas {
+			addrs = append(addrs, addr)
+		}
+		sort.Strings(addrs)
+		return nil, fmt.Errorf("%s contains %d providers %q, specify one by -provider-address", path, len(schemas), addrs)
+	}
+	for _, sch := range schemas {
+		return sch, nil
+	}
+	return nil, nil
+}
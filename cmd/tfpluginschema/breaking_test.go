@@ -0,0 +1,35 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadProviderSchemaFile(t *testing.T) {
+	dir := t.TempDir()
+
+	native := filepath.Join(dir, "native.json")
+	require.NoError(t, os.WriteFile(native, []byte(`{"resource_schemas": {"foo_a": {"block": {}}}}`), 0644))
+	sch, err := readProviderSchemaFile(native, "")
+	require.NoError(t, err)
+	require.Contains(t, sch.ResourceSchemas, "foo_a")
+
+	terraform := filepath.Join(dir, "terraform.json")
+	require.NoError(t, os.WriteFile(terraform, []byte(`{
+  "format_version": "1.0",
+  "provider_schemas": {
+    "registry.terraform.io/hashicorp/foo": {"resource_schemas": {"foo_a": {"version": 0, "block": {}}}},
+    "registry.terraform.io/hashicorp/bar": {"resource_schemas": {"bar_a": {"version": 0, "block": {}}}}
+  }
+}`), 0644))
+	_, err = readProviderSchemaFile(terraform, "")
+	require.ErrorContains(t, err, "specify one by -provider-address")
+	sch, err = readProviderSchemaFile(terraform, "registry.terraform.io/hashicorp/bar")
+	require.NoError(t, err)
+	require.Contains(t, sch.ResourceSchemas, "bar_a")
+	_, err = readProviderSchemaFile(terraform, "registry.terraform.io/hashicorp/baz")
+	require.Error(t, err)
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/magodo/tfpluginschema"
+	"github.com/magodo/tfpluginschema/schema"
+)
+
+const (
+	formatJSON      = "json"
+	formatTerraform = "terraform"
+)
+
+// stringsFlag is a flag that can be specified multiple times.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(v string) error {
+	*f = append(*f, v)
+	return nil
+}
+
+type dumpOptions struct {
+	loader          providerLoader
+	output          string
+	format          string
+	pretty          bool
+	providerAddress string
+	extensions      bool
+	resources       stringsFlag
+	dataSources     stringsFlag
+	ephemerals      stringsFlag
+}
+
+func runDump(ctx context.Context, args []string) error {
+	var opts dumpOptions
+	fs := flag.NewFlagSet("dump", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), `Dump the schema of a provider.
+
+Usage: tfpluginschema dump [options] <provider constructor>
+
+Arguments:
+    provider constructor
+        The fully qualified provider constructor, in form of <package path>.<symbol>.
+        The symbol can be a SDKv2 provider function (e.g. github.com/hashicorp/terraform-provider-azurerm/internal/provider.AzureProvider),
+        a framework provider function (e.g. github.com/Azure/terraform-provider-azapi/internal/provider.New),
+        or a framework provider type (e.g. github.com/Azure/terraform-provider-azapi/internal/provider.Provider).
+
+Options:
+`)
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&opts.loader.Dir, "dir", ".", "A directory inside the provider module")
+	fs.StringVar(&opts.loader.Version, "tfpluginschema-version", "", "The tfpluginschema version used to dump the schema (default to the version of this command, required for a development build unless -tfpluginschema-dir is specified)")
+	fs.StringVar(&opts.loader.LocalDir, "tfpluginschema-dir", "", "The local tfpluginschema source directory used to dump the schema, which takes precedence over -tfpluginschema-version")
+	fs.StringVar(&opts.output, "o", "", "The output file (default to stdout)")
+	fs.StringVar(&opts.format, "format", formatJSON, fmt.Sprintf("The output format, one of %q (tfpluginschema) and %q (terraform providers schema -json)", formatJSON, formatTerraform))
+	fs.BoolVar(&opts.pretty, "pretty", false, "Pretty print the output")
+	fs.StringVar(&opts.providerAddress, "provider-address", "", fmt.Sprintf("The provider source address (e.g. registry.terraform.io/hashicorp/azurerm), required for the %q format", formatTerraform))
+	fs.BoolVar(&opts.extensions, "extensions", false, fmt.Sprintf("Include the tfpluginschema extensions in the %q format", formatTerraform))
+	fs.Var(&opts.resources, "resource", "Only dump the resources matching the glob pattern, can be specified multiple times")
+	fs.Var(&opts.dataSources, "data-source", "Only dump the data sources matching the glob pattern, can be specified multiple times")
+	fs.Var(&opts.ephemerals, "ephemeral-resource", "Only dump the ephemeral resources matching the glob pattern, can be specified multiple times")
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expect 1 argument, got %d", fs.NArg())
+	}
+	switch opts.format {
+	case formatJSON:
+	case formatTerraform:
+		if opts.providerAddress == "" {
+			return fmt.Errorf("-provider-address is required for the %q format", formatTerraform)
+		}
+	default:
+		return fmt.Errorf("unknown format %q", opts.format)
+	}
+	for _, pattern := range append(append(opts.resources, opts.dataSources...), opts.ephemerals...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %v", pattern, err)
+		}
+	}
+
+	sch, err := opts.loader.Load(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	filterSchema(sch, opts.resources, opts.dataSources, opts.ephemerals)
+
+	b, err := opts.marshal(sch)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	if opts.output == "" {
+		_, err := os.Stdout.Write(b)
+		return err
+	}
+	return os.WriteFile(opts.output, b, 0644)
+}
+
+func (opts dumpOptions) marshal(sch *schema.ProviderSchema) ([]byte, error) {
+	var (
+		b   []byte
+		err error
+	)
+	switch opts.format {
+	case formatTerraform:
+		b, err = tfpluginschema.ToTerraformProvidersSchemaJSON(map[string]*schema.ProviderSchema{opts.providerAddress: sch}, opts.extensions)
+	default:
+		b, err = json.Marshal(sch)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !opts.pretty {
+		return b, nil
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, b, "", "  "); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// filterSchema only keeps the resources, data sources and ephemeral resources whose name matches any of the glob patterns.
+// If there is no pattern specified at all, nothing is filtered. Otherwise, the resources (or data sources)
+// are all removed if there is no pattern specified for them.
+func filterSchema(sch *schema.ProviderSchema, resources, dataSources, ephemerals []string) {
+	if len(resources) == 0 && len(dataSources) == 0 && len(ephemerals) == 0 {
+		return
+	}
+	sch.ResourceSchemas = filterSchemas(sch.ResourceSchemas, resources)
+	sch.DataSourceSchemas = filterSchemas(sch.DataSourceSchemas, dataSources)
+	sch.EphemeralResourceSchemas = filterSchemas(sch.EphemeralResourceSchemas, ephemerals)
+}
+
+func filterSchemas(schemas map[string]*schema.Schema, patterns []string) map[string]*schema.Schema {
+	out := map[string]*schema.Schema{}
+	for name, sch := range schemas {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, name); ok {
+				out[name] = sch
+				break
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterSchema(t *testing.T) {
+	newSchema := func() *schema.ProviderSchema {
+		return &schema.ProviderSchema{
+			ResourceSchemas: map[string]*schema.Schema{
+				"foo_a": {},
+				"foo_b": {},
+				"bar_a": {},
+			},
+			DataSourceSchemas: map[string]*schema.Schema{
+				"foo_a": {},
+				"bar_a": {},
+			},
+			EphemeralResourceSchemas: map[string]*schema.Schema{
+				"foo_a": {},
+			},
+		}
+	}
+
+	keys := func(m map[string]*schema.Schema) []string {
+		var out []string
+		for k := range m {
+			out = append(out, k)
+		}
+		return out
+	}
+
+	sch := newSchema()
+	filterSchema(sch, nil, nil, nil)
+	require.Equal(t, newSchema(), sch)
+
+	sch = newSchema()
+	filterSchema(sch, []string{"foo_*"}, nil, nil)
+	require.ElementsMatch(t, []string{"foo_a", "foo_b"}, keys(sch.ResourceSchemas))
+	require.Empty(t, sch.DataSourceSchemas)
+	require.Empty(t, sch.EphemeralResourceSchemas)
+
+	sch = newSchema()
+	filterSchema(sch, []string{"foo_b", "bar_a"}, []string{"*_a"}, nil)
+	require.ElementsMatch(t, []string{"foo_b", "bar_a"}, keys(sch.ResourceSchemas))
+	require.ElementsMatch(t, []string{"foo_a", "bar_a"}, keys(sch.DataSourceSchemas))
+	require.Empty(t, sch.EphemeralResourceSchemas)
+
+	sch = newSchema()
+	filterSchema(sch, nil, nil, []string{"foo_*"})
+	require.Empty(t, sch.ResourceSchemas)
+	require.Empty(t, sch.DataSourceSchemas)
+	require.ElementsMatch(t, []string{"foo_a"}, keys(sch.EphemeralResourceSchemas))
+}
@@ -0,0 +1,84 @@
+// Command tfpluginschema works with the provider schemas defined in tfpluginschema.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+)
+
+type command struct {
+	synopsis string
+	run      func(ctx context.Context, args []string) error
+}
+
+var commands = map[string]command{
+	"breaking": {
+		synopsis: "Report the breaking changes between two provider schemas",
+		run:      runBreaking,
+	},
+	"dump": {
+		synopsis: "Dump the schema of a SDKv2 or framework based provider",
+		run:      runDump,
+	},
+	"scaffold": {
+		synopsis: "Generate the skeleton configuration of resources or data sources",
+		run:      runScaffold,
+	},
+}
+
+// exitError makes the command exit with the code. The error message (if any) is printed before exiting.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e exitError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit code %d", e.code)
+	}
+	return e.err.Error()
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: tfpluginschema <command> [options] [arguments]\n\nCommands:\n")
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "    %-12s%s\n", name, commands[name].synopsis)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun \"tfpluginschema <command> -h\" for the usage of a command.\n")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	name := os.Args[1]
+	if name == "-h" || name == "--help" || name == "help" {
+		usage()
+		return
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd.run(context.Background(), os.Args[2:]); err != nil {
+		code := 1
+		if e, ok := err.(exitError); ok {
+			code = e.code
+			err = e.err
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		os.Exit(code)
+	}
+}
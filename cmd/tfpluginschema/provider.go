@@ -0,0 +1,296 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"text/template"
+
+	"github.com/magodo/tfpluginschema/schema"
+)
+
+const modulePath = "github.com/magodo/tfpluginschema"
+
+// dumperDir is the directory, relative to the provider module root, where the dumper program is placed.
+// The program only exists in the build overlay, nothing is written to the provider tree.
+const dumperDir = ".tfpluginschema-dump"
+
+// providerLoader builds and runs a program that dumps the schema of a provider, in the context of the provider module.
+type providerLoader struct {
+	// Dir is a directory inside the provider module.
+	Dir string
+	// Version is the version of tfpluginschema used by the dumper program. Defaults to the version of this command.
+	Version string
+	// LocalDir is the path to a local tfpluginschema source tree used by the dumper program. It takes precedence over Version.
+	LocalDir string
+}
+
+// Load loads the provider schema of the provider constructor, which is in form of "<package path>.<symbol>".
+// The symbol can be a function that returns the provider (e.g. SDKv2 "provider.Provider", or framework "provider.New"),
+// or a type that implements the framework provider (e.g. "provider.Provider").
+func (l providerLoader) Load(ctx context.Context, constructor string) (*schema.ProviderSchema, error) {
+	pkgPath, symbol, err := splitConstructor(constructor)
+	if err != nil {
+		return nil, err
+	}
+	version := l.Version
+	if version == "" && l.LocalDir == "" {
+		// Fail before building anything, as the dumper program can't be built against an unknown tfpluginschema.
+		if version, err = defaultVersion(); err != nil {
+			return nil, err
+		}
+	}
+
+	dir, err := filepath.Abs(l.Dir)
+	if err != nil {
+		return nil, err
+	}
+	gomod, err := l.goCmdOutput(ctx, dir, "env", "GOMOD")
+	if err != nil {
+		return nil, err
+	}
+	gomod = strings.TrimSpace(gomod)
+	if gomod == "" || gomod == os.DevNull {
+		return nil, fmt.Errorf("%s is not inside a Go module", dir)
+	}
+	root := filepath.Dir(gomod)
+
+	tmpDir, err := os.MkdirTemp("", "tfpluginschema-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// Work on a copy of the go.mod and go.sum, so that the provider module is left untouched.
+	modfile := filepath.Join(tmpDir, "go.mod")
+	if err := copyFile(gomod, modfile); err != nil {
+		return nil, err
+	}
+	if err := copyFile(filepath.Join(root, "go.sum"), filepath.Join(tmpDir, "go.sum")); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err := l.require(ctx, root, modfile, version); err != nil {
+		return nil, err
+	}
+
+	out, err := l.goCmdOutput(ctx, root, "list", "-modfile="+modfile, "-f", "{{.Dir}}", pkgPath)
+	if err != nil {
+		return nil, err
+	}
+	expr, err := constructorExpr(strings.TrimSpace(out), symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := generateDumper(pkgPath, expr)
+	if err != nil {
+		return nil, err
+	}
+	mainFile := filepath.Join(tmpDir, "main.go")
+	if err := os.WriteFile(mainFile, src, 0644); err != nil {
+		return nil, err
+	}
+	overlay, err := json.Marshal(map[string]interface{}{
+		"Replace": map[string]string{
+			filepath.Join(root, dumperDir, "main.go"): mainFile,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	overlayFile := filepath.Join(tmpDir, "overlay.json")
+	if err := os.WriteFile(overlayFile, overlay, 0644); err != nil {
+		return nil, err
+	}
+
+	out, err = l.goCmdOutput(ctx, root, "run", "-modfile="+modfile, "-overlay="+overlayFile, "./"+dumperDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var sch schema.ProviderSchema
+	if err := json.Unmarshal([]byte(out), &sch); err != nil {
+		return nil, fmt.Errorf("unmarshalling the provider schema: %v", err)
+	}
+	return &sch, nil
+}
+
+// require adds tfpluginschema to the requirements of the (copied) provider go.mod, either replaced by the LocalDir,
+// or in the specified version.
+func (l providerLoader) require(ctx context.Context, root, modfile, version string) error {
+	if l.LocalDir != "" {
+		localDir, err := filepath.Abs(l.LocalDir)
+		if err != nil {
+			return err
+		}
+		_, err = l.goCmdOutput(ctx, root, "mod", "edit", "-modfile="+modfile,
+			"-require="+modulePath+"@v0.0.0-00010101000000-000000000000",
+			"-replace="+modulePath+"="+localDir,
+		)
+		return err
+	}
+	_, err := l.goCmdOutput(ctx, root, "get", "-modfile="+modfile, modulePath+"@"+version)
+	return err
+}
+
+func (l providerLoader) goCmdOutput(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+	// The provider's vendor directory (if any) doesn't contain tfpluginschema, and the workspace is not what we want to build against.
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("running \"go %s\": %v\n%s", strings.Join(args, " "), err, stderr.String())
+	}
+	return stdout.String(), nil
+}
+
+// defaultVersion returns the version of tfpluginschema that this command is built from. A development build has no
+// module version, in which case the dumper program might use APIs that are not published yet, so an error is returned.
+func defaultVersion() (string, error) {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Path == modulePath {
+		if v := info.Main.Version; v != "" && v != "(devel)" {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("this command is built without a tfpluginschema module version, please specify either -tfpluginschema-dir or -tfpluginschema-version")
+}
+
+// splitConstructor splits the provider constructor (e.g. github.com/foo/terraform-provider-bar/internal/provider.Provider)
+// into the package path and the symbol name.
+func splitConstructor(constructor string) (string, string, error) {
+	idx := strings.LastIndex(constructor, ".")
+	if idx == -1 || idx < strings.LastIndex(constructor, "/") || idx == len(constructor)-1 {
+		return "", "", fmt.Errorf("invalid provider constructor %q, expect <package path>.<symbol>", constructor)
+	}
+	return constructor[:idx], constructor[idx+1:], nil
+}
+
+// constructorExpr looks up the symbol in the package source directory, and returns the Go expression
+// that refers to the provider in the dumper program, where the package is imported as "target".
+func constructorExpr(pkgDir, symbol string) (string, error) {
+	pkgs, err := parser.ParseDir(token.NewFileSet(), pkgDir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return "", fmt.Errorf("parsing package in %s: %v", pkgDir, err)
+	}
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			for _, decl := range f.Decls {
+				switch decl := decl.(type) {
+				case *ast.FuncDecl:
+					if decl.Recv == nil && decl.Name.Name == symbol {
+						if decl.Type.Params.NumFields() != 0 {
+							return "", fmt.Errorf("function %s is expected to take no argument", symbol)
+						}
+						return "target." + symbol, nil
+					}
+				case *ast.GenDecl:
+					for _, spec := range decl.Specs {
+						switch spec := spec.(type) {
+						case *ast.TypeSpec:
+							if spec.Name.Name == symbol {
+								return "&target." + symbol + "{}", nil
+							}
+						case *ast.ValueSpec:
+							for _, name := range spec.Names {
+								if name.Name == symbol {
+									return "target." + symbol, nil
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("symbol %s not found in %s", symbol, pkgDir)
+}
+
+var dumperTemplate = template.Must(template.New("dumper").Parse(`package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/magodo/tfpluginschema"
+	"github.com/magodo/tfpluginschema/schema"
+	target {{ printf "%q" .PkgPath }}
+)
+
+func main() {
+	sch, err := providerSchema({{ .Expr }})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	b, err := json.Marshal(sch)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Stdout.Write(b)
+}
+
+func providerSchema(p interface{}) (*schema.ProviderSchema, error) {
+	// Call the constructor function(s) until getting the provider.
+	for {
+		rv := reflect.ValueOf(p)
+		if rv.Kind() != reflect.Func || rv.Type().NumIn() != 0 || rv.Type().NumOut() != 1 {
+			break
+		}
+		p = rv.Call(nil)[0].Interface()
+	}
+	switch p := p.(type) {
+	case *sdkschema.Provider:
+		return tfpluginschema.FromSDKv2ProviderE(p)
+	case provider.Provider:
+		return tfpluginschema.FromFWProvider(p)
+	case tfprotov6.ProviderServer:
+		return tfpluginschema.FromProtoV6Provider(context.Background(), p)
+	case tfprotov5.ProviderServer:
+		return tfpluginschema.FromProtoV5Provider(context.Background(), p)
+	default:
+		return nil, fmt.Errorf("unsupported provider type %T", p)
+	}
+}
+`))
+
+// generateDumper generates the source of the dumper program.
+func generateDumper(pkgPath, expr string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := dumperTemplate.Execute(&buf, struct {
+		PkgPath string
+		Expr    string
+	}{pkgPath, expr}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func copyFile(src, dst string) error {
+	b, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, b, 0644)
+}
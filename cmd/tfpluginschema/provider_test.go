@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitConstructor(t *testing.T) {
+	pkg, symbol, err := splitConstructor("github.com/foo/terraform-provider-bar/internal/provider.Provider")
+	require.NoError(t, err)
+	require.Equal(t, "github.com/foo/terraform-provider-bar/internal/provider", pkg)
+	require.Equal(t, "Provider", symbol)
+
+	for _, input := range []string{"Provider", "github.com/foo/bar", "github.com/foo/bar.", "example.com/foo"} {
+		_, _, err := splitConstructor(input)
+		require.Error(t, err, input)
+	}
+}
+
+func TestConstructorExpr(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "provider.go"), []byte(`package provider
+
+type Provider struct{}
+
+func New() func() *Provider { return func() *Provider { return &Provider{} } }
+
+func NewWithVersion(version string) *Provider { return &Provider{} }
+
+var Default = &Provider{}
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "provider_test.go"), []byte(`package provider
+
+func TestOnly() *Provider { return nil }
+`), 0644))
+
+	cases := map[string]string{
+		"Provider": "&target.Provider{}",
+		"New":      "target.New",
+		"Default":  "target.Default",
+	}
+	for symbol, expect := range cases {
+		expr, err := constructorExpr(dir, symbol)
+		require.NoError(t, err, symbol)
+		require.Equal(t, expect, expr, symbol)
+	}
+
+	for _, symbol := range []string{"NewWithVersion", "TestOnly", "NotExist"} {
+		_, err := constructorExpr(dir, symbol)
+		require.Error(t, err, symbol)
+	}
+}
+
+func TestGenerateDumper(t *testing.T) {
+	src, err := generateDumper("example.com/foo/internal/provider", "&target.Provider{}")
+	require.NoError(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), "main.go", src, 0)
+	require.NoError(t, err)
+}
+
+func TestProviderLoaderLoad(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping building the dumper program in short mode")
+	}
+
+	localDir, err := filepath.Abs("../..")
+	require.NoError(t, err)
+
+	// A tiny SDKv2 provider module, which reuses the go.sum of tfpluginschema so that no checksum needs to be looked up.
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte(`module example.com/terraform-provider-foo
+
+go 1.22.0
+
+require github.com/hashicorp/terraform-plugin-sdk/v2 v2.34.0
+`), 0644))
+	require.NoError(t, copyFile(filepath.Join(localDir, "go.sum"), filepath.Join(dir, "go.sum")))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "internal", "provider"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "internal", "provider", "provider.go"), []byte(`package provider
+
+import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"foo_resource": {
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+						ForceNew: true,
+					},
+					"size": {
+						Type:     schema.TypeInt,
+						Optional: true,
+						Default:  1,
+					},
+				},
+			},
+		},
+	}
+}
+`), 0644))
+	gomod, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	require.NoError(t, err)
+
+	loader := providerLoader{Dir: filepath.Join(dir, "internal"), LocalDir: localDir}
+	sch, err := loader.Load(context.Background(), "example.com/terraform-provider-foo/internal/provider.Provider")
+	require.NoError(t, err)
+
+	res := sch.ResourceSchemas["foo_resource"]
+	require.NotNil(t, res)
+	attrs := res.Block.Attributes
+	require.Len(t, attrs, 2)
+	require.Equal(t, "name", attrs[0].Name)
+	require.True(t, attrs[0].Required)
+	require.True(t, *attrs[0].ForceNew)
+	require.Equal(t, "size", attrs[1].Name)
+	require.Equal(t, int64(1), attrs[1].Default)
+
+	// The provider module is left untouched.
+	b, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	require.NoError(t, err)
+	require.Equal(t, string(gomod), string(b))
+	_, err = os.Stat(filepath.Join(dir, dumperDir))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestProviderLoaderLoad_DefaultVersion(t *testing.T) {
+	// The test binary is a development build, which has no module version to default to.
+	loader := providerLoader{Dir: t.TempDir()}
+	_, err := loader.Load(context.Background(), "example.com/terraform-provider-foo/internal/provider.Provider")
+	require.ErrorContains(t, err, "-tfpluginschema-dir")
+	require.ErrorContains(t, err, "-tfpluginschema-version")
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/magodo/tfpluginschema/scaffold"
+	"github.com/magodo/tfpluginschema/schema"
+)
+
+func runScaffold(ctx context.Context, args []string) error {
+	var (
+		schemaFile      string
+		providerAddress string
+		output          string
+		dataSource      bool
+		opts            scaffold.Options
+	)
+	fs := flag.NewFlagSet("scaffold", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), `Generate the skeleton configuration of the resources (or data sources).
+
+Usage: tfpluginschema scaffold [options] <type>...
+
+The required arguments are filled with placeholders, while the optional arguments and blocks are commented out,
+unless they have a default value.
+
+Options:
+`)
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&schemaFile, "schema", "schema.json", `The provider schema file, which is either dumped by "tfpluginschema dump", or by "terraform providers schema -json"`)
+	fs.StringVar(&providerAddress, "provider-address", "", "The provider source address, required if the terraform providers schema file contains multiple providers")
+	fs.StringVar(&output, "o", "", "The output file (default to stdout)")
+	fs.BoolVar(&dataSource, "data-source", false, "Generate the data sources instead of the resources")
+	fs.StringVar(&opts.Name, "name", scaffold.DefaultName, "The name label of the generated blocks")
+	fs.BoolVar(&opts.RequiredOnly, "required-only", false, "Omit the optional arguments and blocks, instead of commenting them out")
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("expect at least 1 argument, got 0")
+	}
+
+	sch, err := readProviderSchemaFile(schemaFile, providerAddress)
+	if err != nil {
+		return err
+	}
+
+	b, err := scaffoldConfig(sch, fs.Args(), dataSource, &opts)
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		_, err := os.Stdout.Write(b)
+		return err
+	}
+	return os.WriteFile(output, b, 0644)
+}
+
+// scaffoldConfig generates the skeleton configuration of the resources (or data sources), separated by empty lines.
+func scaffoldConfig(sch *schema.ProviderSchema, types []string, dataSource bool, opts *scaffold.Options) ([]byte, error) {
+	schemas, gen, kind := sch.ResourceSchemas, scaffold.Resource, "resource"
+	if dataSource {
+		schemas, gen, kind = sch.DataSourceSchemas, scaffold.DataSource, "data source"
+	}
+
+	var out [][]byte
+	for _, typ := range types {
+		s, ok := schemas[typ]
+		if !ok {
+			return nil, fmt.Errorf("%s %q not found", kind, typ)
+		}
+		b, err := gen(typ, s, opts)
+		if err != nil {
+			return nil, fmt.Errorf("generating %s %q: %v", kind, typ, err)
+		}
+		out = append(out, b)
+	}
+	return bytes.Join(out, []byte("\n")), nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestScaffoldConfig(t *testing.T) {
+	str := cty.String
+	sch := &schema.ProviderSchema{
+		ResourceSchemas: map[string]*schema.Schema{
+			"foo_a": {Block: &schema.SchemaBlock{Attributes: []*schema.SchemaAttribute{{Name: "name", Type: &str, Required: true}}}},
+			"foo_b": {Block: &schema.SchemaBlock{}},
+		},
+		DataSourceSchemas: map[string]*schema.Schema{
+			"foo_a": {Block: &schema.SchemaBlock{Attributes: []*schema.SchemaAttribute{{Name: "id", Type: &str, Required: true}}}},
+		},
+	}
+
+	b, err := scaffoldConfig(sch, []string{"foo_a", "foo_b"}, false, nil)
+	require.NoError(t, err)
+	require.Equal(t, `resource "foo_a" "example" {
+  name = ""
+}
+
+resource "foo_b" "example" {
+}
+`, string(b))
+
+	b, err = scaffoldConfig(sch, []string{"foo_a"}, true, nil)
+	require.NoError(t, err)
+	require.Equal(t, `data "foo_a" "example" {
+  id = ""
+}
+`, string(b))
+
+	_, err = scaffoldConfig(sch, []string{"foo_b"}, true, nil)
+	require.ErrorContains(t, err, `data source "foo_b" not found`)
+}
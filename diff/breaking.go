@@ -0,0 +1,265 @@
+package diff
+
+import (
+	"fmt"
+
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// Severity tells how a change impacts the existing user configs or states.
+type Severity int
+
+const (
+	SeveritySafe Severity = iota + 1
+	SeverityPotentiallyBreaking
+	SeverityBreaking
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeveritySafe:
+		return "safe"
+	case SeverityPotentiallyBreaking:
+		return "potentially breaking"
+	case SeverityBreaking:
+		return "breaking"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// ClassifiedChange is a change labeled with its severity.
+type ClassifiedChange struct {
+	Change
+	Severity Severity
+	// Reason explains why the change is labeled as the severity.
+	Reason string
+}
+
+func (c ClassifiedChange) String() string {
+	return fmt.Sprintf("%s: %s (%s)", c.Severity, c.Change, c.Reason)
+}
+
+// ClassifyProviderSchema compares the old and the new provider schema, and classifies each of the changes.
+func ClassifyProviderSchema(old, new *schema.ProviderSchema) []ClassifiedChange {
+	return ClassifyChanges(ProviderSchema(old, new))
+}
+
+// ClassifySchema compares the old and the new schema of a resource, data source or provider, and classifies each of the changes.
+func ClassifySchema(old, new *schema.Schema) []ClassifiedChange {
+	return ClassifyChanges(Schema(old, new))
+}
+
+// ClassifyChanges classifies each of the changes.
+func ClassifyChanges(changes []Change) []ClassifiedChange {
+	var out []ClassifiedChange
+	for _, change := range changes {
+		severity, reason := Classify(change)
+		out = append(out, ClassifiedChange{Change: change, Severity: severity, Reason: reason})
+	}
+	return out
+}
+
+// MaxSeverity returns the highest severity among the changes, or 0 if there is no change.
+func MaxSeverity(changes []ClassifiedChange) Severity {
+	var max Severity
+	for _, change := range changes {
+		if change.Severity > max {
+			max = change.Severity
+		}
+	}
+	return max
+}
+
+// Classify labels the change as breaking, potentially breaking or safe, with a reason.
+func Classify(c Change) (Severity, string) {
+	switch c.Element {
+	case ElementKindSchema:
+		return classifySchema(c)
+	case ElementKindAttribute:
+		return classifyAttribute(c)
+	case ElementKindBlock:
+		return classifyBlock(c)
+	default:
+		return SeverityPotentiallyBreaking, "unknown change"
+	}
+}
+
+func classifySchema(c Change) (Severity, string) {
+	switch c.Kind {
+	case ChangeKindAdded:
+		return SeveritySafe, fmt.Sprintf("new %s", c.SchemaKind)
+	case ChangeKindRemoved:
+		return SeverityBreaking, fmt.Sprintf("%s is removed, the existing configs using it are invalid", c.SchemaKind)
+	}
+	switch c.Property {
+	case PropertySchemaVersion:
+		if c.New.(int64) > c.Old.(int64) {
+			return SeveritySafe, "the existing states are upgraded by the provider"
+		}
+		return SeverityBreaking, "schema version is decreased, the existing states can't be decoded"
+	case PropertyDeprecationMessage:
+		return SeveritySafe, "deprecation only produces warnings"
+	}
+	return SeverityPotentiallyBreaking, fmt.Sprintf("%s is changed", c.Property)
+}
+
+func classifyAttribute(c Change) (Severity, string) {
+	switch c.Kind {
+	case ChangeKindAdded:
+		if attr := c.NewElement.(*schema.SchemaAttribute); attr.Required {
+			return SeverityBreaking, "new required attribute, the existing configs don't set it"
+		}
+		return SeveritySafe, "new optional or computed attribute"
+	case ChangeKindRemoved:
+		if attr := c.OldElement.(*schema.SchemaAttribute); !attr.Required && !attr.Optional {
+			return SeverityPotentiallyBreaking, "computed attribute is removed, the existing configs referencing it are invalid"
+		}
+		return SeverityBreaking, "attribute is removed, the existing configs setting it are invalid"
+	}
+
+	oldAttr, newAttr := c.OldElement.(*schema.SchemaAttribute), c.NewElement.(*schema.SchemaAttribute)
+	switch c.Property {
+	case PropertyType:
+		return classifyType(c.Old.(cty.Type), c.New.(cty.Type))
+	case PropertyNestingMode:
+		return SeverityBreaking, "nesting mode is changed, the existing configs and states don't conform to the new structure"
+	case PropertyRequired, PropertyOptional, PropertyComputed:
+		return classifyConfigurability(
+			configurability{oldAttr.Required, oldAttr.Optional, oldAttr.Computed},
+			configurability{newAttr.Required, newAttr.Optional, newAttr.Computed},
+			c.Property,
+		)
+	case PropertySensitive:
+		if c.New.(bool) {
+			return SeverityPotentiallyBreaking, "attribute becomes sensitive, the outputs referencing it need to be marked as sensitive"
+		}
+		return SeveritySafe, "attribute is no longer sensitive"
+	case PropertyDefault:
+		return SeverityPotentiallyBreaking, "default is changed, the existing resources relying on the default will have a diff"
+	}
+	return classifyCommon(c)
+}
+
+func classifyBlock(c Change) (Severity, string) {
+	switch c.Kind {
+	case ChangeKindAdded:
+		if blk := c.NewElement.(*schema.SchemaNestedBlock); blk.MinItems > 0 || boolValue(blk.Required) {
+			return SeverityBreaking, "new required block, the existing configs don't set it"
+		}
+		return SeveritySafe, "new optional or computed block"
+	case ChangeKindRemoved:
+		return SeverityBreaking, "block is removed, the existing configs setting it are invalid"
+	}
+
+	oldBlk, newBlk := c.OldElement.(*schema.SchemaNestedBlock), c.NewElement.(*schema.SchemaNestedBlock)
+	switch c.Property {
+	case PropertyNestingMode:
+		return SeverityBreaking, "nesting mode is changed, the existing configs and states don't conform to the new structure"
+	case PropertyMinItems:
+		if c.New.(int) > c.Old.(int) {
+			return SeverityBreaking, "min items is increased, the existing configs with fewer blocks are invalid"
+		}
+		return SeveritySafe, "min items is decreased"
+	case PropertyMaxItems:
+		// A zero MaxItems means unlimited.
+		oldMax, newMax := c.Old.(int), c.New.(int)
+		if newMax != 0 && (oldMax == 0 || newMax < oldMax) {
+			return SeverityBreaking, "max items is decreased, the existing configs with more blocks are invalid"
+		}
+		return SeveritySafe, "max items is increased"
+	case PropertyRequired, PropertyOptional, PropertyComputed:
+		return classifyConfigurability(
+			configurability{boolValue(oldBlk.Required), boolValue(oldBlk.Optional), boolValue(oldBlk.Computed)},
+			configurability{boolValue(newBlk.Required), boolValue(newBlk.Optional), boolValue(newBlk.Computed)},
+			c.Property,
+		)
+	}
+	return classifyCommon(c)
+}
+
+// classifyCommon classifies the property changes that are common to the attributes and blocks.
+func classifyCommon(c Change) (Severity, string) {
+	switch c.Property {
+	case PropertyForceNew:
+		if c.New.(bool) {
+			return SeverityBreaking, "force new is set, updating it will replace the existing resources"
+		}
+		return SeveritySafe, "force new is unset, it can be updated in-place"
+	case PropertyRequiresReplaceIf:
+		if c.New.(bool) {
+			return SeverityPotentiallyBreaking, "conditional replacement is set, updating it might replace the existing resources"
+		}
+		return SeveritySafe, "conditional replacement is unset"
+	case PropertyDeprecationMessage:
+		return SeveritySafe, "deprecation only produces warnings"
+	case PropertyConflictsWith, PropertyRequiredWith:
+		if added := addedStrings(c.Old.([]string), c.New.([]string)); len(added) != 0 {
+			return SeverityBreaking, fmt.Sprintf("%s is added with %q, the existing configs might violate it", c.Property, added)
+		}
+		return SeveritySafe, fmt.Sprintf("%s is loosened", c.Property)
+	case PropertyExactlyOneOf, PropertyAtLeastOneOf:
+		return SeverityPotentiallyBreaking, fmt.Sprintf("%s is changed, the existing configs might violate it", c.Property)
+	}
+	return SeverityPotentiallyBreaking, fmt.Sprintf("%s is changed", c.Property)
+}
+
+func classifyType(old, new cty.Type) (Severity, string) {
+	if old == cty.NilType || new == cty.NilType {
+		return SeverityBreaking, "type is changed"
+	}
+	if convert.GetConversion(old, new) == nil {
+		return SeverityBreaking, fmt.Sprintf("type is narrowed, the existing %s values can't be converted to %s", old.FriendlyName(), new.FriendlyName())
+	}
+	return SeverityPotentiallyBreaking, fmt.Sprintf("type is widened, the existing configs referencing it as %s might be invalid", old.FriendlyName())
+}
+
+type configurability struct {
+	required, optional, computed bool
+}
+
+// classifyConfigurability classifies the change of the required, optional and computed property, based on the overall
+// configurability before and after the change, as these properties are usually changed together.
+func classifyConfigurability(old, new configurability, property string) (Severity, string) {
+	switch property {
+	case PropertyRequired:
+		if new.required {
+			return SeverityBreaking, "it becomes required, the existing configs not setting it are invalid"
+		}
+		return SeveritySafe, "it is no longer required"
+	case PropertyOptional:
+		if new.optional {
+			return SeveritySafe, "it becomes optional"
+		}
+		if new.required {
+			// The breaking part is reported by the required change.
+			return SeveritySafe, "it is no longer optional as it becomes required"
+		}
+		return SeverityBreaking, "it can no longer be set, the existing configs setting it are invalid"
+	default:
+		if new.computed {
+			return SeveritySafe, "it becomes computed"
+		}
+		if old.optional || old.required {
+			return SeverityPotentiallyBreaking, "it is no longer computed, the existing resources not setting it will have a diff"
+		}
+		return SeverityPotentiallyBreaking, "it is no longer computed"
+	}
+}
+
+// addedStrings returns the strings in new but not in old.
+func addedStrings(old, new []string) []string {
+	m := map[string]bool{}
+	for _, s := range old {
+		m[s] = true
+	}
+	var out []string
+	for _, s := range new {
+		if !m[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
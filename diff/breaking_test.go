@@ -0,0 +1,165 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/magodo/tfpluginschema/diff"
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestClassifyProviderSchema(t *testing.T) {
+	changes := diff.ClassifyProviderSchema(oldProviderSchema(), newProviderSchema())
+
+	var got []string
+	for _, change := range changes {
+		got = append(got, change.Severity.String()+": "+change.Address())
+	}
+	require.Equal(t, []string{
+		"breaking: foo_removed",
+		"safe: foo_resource",
+		"safe: foo_resource.added",
+		"breaking: foo_resource.name",
+		"safe: foo_resource.name",
+		"breaking: foo_resource.name",
+		"breaking: foo_resource.nested",
+		"breaking: foo_resource.nested.x",
+		"breaking: foo_resource.removed",
+		"potentially breaking: foo_resource.size",
+		"breaking: foo_resource.rule",
+		"breaking: foo_resource.rule",
+		"potentially breaking: foo_resource.rule.priority",
+		"safe: foo_data",
+	}, got)
+	require.Equal(t, diff.SeverityBreaking, diff.MaxSeverity(changes))
+}
+
+func TestClassifySchema(t *testing.T) {
+	block := func(attrs []*schema.SchemaAttribute, blocks []*schema.SchemaNestedBlock) *schema.Schema {
+		return &schema.Schema{Block: &schema.SchemaBlock{Attributes: attrs, BlockTypes: blocks}}
+	}
+
+	cases := []struct {
+		name     string
+		old      *schema.Schema
+		new      *schema.Schema
+		severity diff.Severity
+		reason   string
+	}{
+		{
+			name:     "optional becomes required",
+			old:      block([]*schema.SchemaAttribute{{Name: "a", Type: ToPtr(cty.String), Optional: true}}, nil),
+			new:      block([]*schema.SchemaAttribute{{Name: "a", Type: ToPtr(cty.String), Required: true}}, nil),
+			severity: diff.SeverityBreaking,
+			reason:   "it becomes required, the existing configs not setting it are invalid",
+		},
+		{
+			name:     "required becomes optional",
+			old:      block([]*schema.SchemaAttribute{{Name: "a", Type: ToPtr(cty.String), Required: true}}, nil),
+			new:      block([]*schema.SchemaAttribute{{Name: "a", Type: ToPtr(cty.String), Optional: true}}, nil),
+			severity: diff.SeveritySafe,
+			reason:   "it is no longer required",
+		},
+		{
+			name:     "optional becomes computed only",
+			old:      block([]*schema.SchemaAttribute{{Name: "a", Type: ToPtr(cty.String), Optional: true, Computed: true}}, nil),
+			new:      block([]*schema.SchemaAttribute{{Name: "a", Type: ToPtr(cty.String), Computed: true}}, nil),
+			severity: diff.SeverityBreaking,
+			reason:   "it can no longer be set, the existing configs setting it are invalid",
+		},
+		{
+			name:     "computed attribute removed",
+			old:      block([]*schema.SchemaAttribute{{Name: "a", Type: ToPtr(cty.String), Computed: true}}, nil),
+			new:      block(nil, nil),
+			severity: diff.SeverityPotentiallyBreaking,
+			reason:   "computed attribute is removed, the existing configs referencing it are invalid",
+		},
+		{
+			name:     "type narrowed",
+			old:      block([]*schema.SchemaAttribute{{Name: "a", Type: ToPtr(cty.List(cty.String)), Optional: true}}, nil),
+			new:      block([]*schema.SchemaAttribute{{Name: "a", Type: ToPtr(cty.String), Optional: true}}, nil),
+			severity: diff.SeverityBreaking,
+			reason:   "type is narrowed, the existing list of string values can't be converted to string",
+		},
+		{
+			name:     "conflicts with grows",
+			old:      block([]*schema.SchemaAttribute{{Name: "a", Type: ToPtr(cty.String), Optional: true, ConflictsWith: []string{"b"}}}, nil),
+			new:      block([]*schema.SchemaAttribute{{Name: "a", Type: ToPtr(cty.String), Optional: true, ConflictsWith: []string{"b", "c"}}}, nil),
+			severity: diff.SeverityBreaking,
+			reason:   `conflicts_with is added with ["c"], the existing configs might violate it`,
+		},
+		{
+			name:     "conflicts with shrinks",
+			old:      block([]*schema.SchemaAttribute{{Name: "a", Type: ToPtr(cty.String), Optional: true, ConflictsWith: []string{"b", "c"}}}, nil),
+			new:      block([]*schema.SchemaAttribute{{Name: "a", Type: ToPtr(cty.String), Optional: true, ConflictsWith: []string{"b"}}}, nil),
+			severity: diff.SeveritySafe,
+			reason:   "conflicts_with is loosened",
+		},
+		{
+			name:     "force new unset",
+			old:      block([]*schema.SchemaAttribute{{Name: "a", Type: ToPtr(cty.String), Optional: true, ForceNew: ToPtr(true)}}, nil),
+			new:      block([]*schema.SchemaAttribute{{Name: "a", Type: ToPtr(cty.String), Optional: true}}, nil),
+			severity: diff.SeveritySafe,
+			reason:   "force new is unset, it can be updated in-place",
+		},
+		{
+			name:     "requires replace if set",
+			old:      block([]*schema.SchemaAttribute{{Name: "a", Type: ToPtr(cty.String), Optional: true}}, nil),
+			new:      block([]*schema.SchemaAttribute{{Name: "a", Type: ToPtr(cty.String), Optional: true, RequiresReplaceIf: ToPtr(true)}}, nil),
+			severity: diff.SeverityPotentiallyBreaking,
+			reason:   "conditional replacement is set, updating it might replace the existing resources",
+		},
+		{
+			name:     "max items shrinks",
+			old:      block(nil, []*schema.SchemaNestedBlock{{TypeName: "b", Nesting: schema.SchemaNestedBlockNestingModeList, MaxItems: 2, Block: &schema.SchemaBlock{}}}),
+			new:      block(nil, []*schema.SchemaNestedBlock{{TypeName: "b", Nesting: schema.SchemaNestedBlockNestingModeList, MaxItems: 1, Block: &schema.SchemaBlock{}}}),
+			severity: diff.SeverityBreaking,
+			reason:   "max items is decreased, the existing configs with more blocks are invalid",
+		},
+		{
+			name:     "max items becomes limited",
+			old:      block(nil, []*schema.SchemaNestedBlock{{TypeName: "b", Nesting: schema.SchemaNestedBlockNestingModeList, Block: &schema.SchemaBlock{}}}),
+			new:      block(nil, []*schema.SchemaNestedBlock{{TypeName: "b", Nesting: schema.SchemaNestedBlockNestingModeList, MaxItems: 1, Block: &schema.SchemaBlock{}}}),
+			severity: diff.SeverityBreaking,
+			reason:   "max items is decreased, the existing configs with more blocks are invalid",
+		},
+		{
+			name:     "max items becomes unlimited",
+			old:      block(nil, []*schema.SchemaNestedBlock{{TypeName: "b", Nesting: schema.SchemaNestedBlockNestingModeList, MaxItems: 1, Block: &schema.SchemaBlock{}}}),
+			new:      block(nil, []*schema.SchemaNestedBlock{{TypeName: "b", Nesting: schema.SchemaNestedBlockNestingModeList, Block: &schema.SchemaBlock{}}}),
+			severity: diff.SeveritySafe,
+			reason:   "max items is increased",
+		},
+		{
+			name:     "optional block added",
+			old:      block(nil, nil),
+			new:      block(nil, []*schema.SchemaNestedBlock{{TypeName: "b", Nesting: schema.SchemaNestedBlockNestingModeList, Block: &schema.SchemaBlock{}}}),
+			severity: diff.SeveritySafe,
+			reason:   "new optional or computed block",
+		},
+		{
+			name:     "required block added",
+			old:      block(nil, nil),
+			new:      block(nil, []*schema.SchemaNestedBlock{{TypeName: "b", Nesting: schema.SchemaNestedBlockNestingModeList, MinItems: 1, Block: &schema.SchemaBlock{}}}),
+			severity: diff.SeverityBreaking,
+			reason:   "new required block, the existing configs don't set it",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := diff.ClassifySchema(tt.old, tt.new)
+			require.NotEmpty(t, changes)
+			// Only check the most severe change, as some properties (e.g. required and optional) are changed together.
+			severity := diff.MaxSeverity(changes)
+			require.Equal(t, tt.severity, severity)
+			for _, change := range changes {
+				if change.Severity == severity {
+					require.Equal(t, tt.reason, change.Reason)
+					break
+				}
+			}
+		})
+	}
+}
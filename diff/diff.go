@@ -0,0 +1,400 @@
+// Package diff compares two versions of the provider schema defined in tfpluginschema.
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+type ChangeKind int
+
+const (
+	ChangeKindAdded ChangeKind = iota + 1
+	ChangeKindRemoved
+	ChangeKindModified
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeKindAdded:
+		return "added"
+	case ChangeKindRemoved:
+		return "removed"
+	case ChangeKindModified:
+		return "modified"
+	default:
+		return fmt.Sprintf("ChangeKind(%d)", int(k))
+	}
+}
+
+type SchemaKind int
+
+const (
+	SchemaKindProvider SchemaKind = iota + 1
+	SchemaKindResource
+	SchemaKindDataSource
+	SchemaKindEphemeralResource
+)
+
+func (k SchemaKind) String() string {
+	switch k {
+	case SchemaKindProvider:
+		return "provider"
+	case SchemaKindResource:
+		return "resource"
+	case SchemaKindDataSource:
+		return "data source"
+	case SchemaKindEphemeralResource:
+		return "ephemeral resource"
+	default:
+		return fmt.Sprintf("SchemaKind(%d)", int(k))
+	}
+}
+
+type ElementKind int
+
+const (
+	ElementKindSchema ElementKind = iota + 1
+	ElementKindAttribute
+	ElementKindBlock
+)
+
+func (k ElementKind) String() string {
+	switch k {
+	case ElementKindSchema:
+		return "schema"
+	case ElementKindAttribute:
+		return "attribute"
+	case ElementKindBlock:
+		return "block"
+	default:
+		return fmt.Sprintf("ElementKind(%d)", int(k))
+	}
+}
+
+// The names of the properties that are compared.
+const (
+	PropertySchemaVersion      = "schema_version"
+	PropertyDeprecationMessage = "deprecation_message"
+	PropertyType               = "type"
+	PropertyRequired           = "required"
+	PropertyOptional           = "optional"
+	PropertyComputed           = "computed"
+	PropertySensitive          = "sensitive"
+	PropertyForceNew           = "force_new"
+	PropertyRequiresReplaceIf  = "requires_replace_if"
+	PropertyDefault            = "default"
+	PropertyNestingMode        = "nesting_mode"
+	PropertyMinItems           = "min_items"
+	PropertyMaxItems           = "max_items"
+	PropertyConflictsWith      = "conflicts_with"
+	PropertyExactlyOneOf       = "exactly_one_of"
+	PropertyAtLeastOneOf       = "at_least_one_of"
+	PropertyRequiredWith       = "required_with"
+)
+
+// Change is a single difference between the old and the new provider schema.
+type Change struct {
+	Kind ChangeKind
+
+	// SchemaKind and SchemaName identify the schema that the change belongs to.
+	// The SchemaName is empty for the provider schema.
+	SchemaKind SchemaKind
+	SchemaName string
+
+	// Element is the kind of the added, removed or modified element.
+	Element ElementKind
+	// Path is the path of the attribute or block from the root block of the schema,
+	// which consists of the attribute and block names. It is empty for the schema itself.
+	Path []string
+
+	// Property is the name of the modified property (e.g. "required"), only set for the modified changes.
+	Property string
+	// Old and New are the old and new values of the modified property.
+	// The *bool properties are reported as bool (nil as false), the default is reported as cty.Value
+	// and the type is reported as cty.Type (the implied type for the nested attributes).
+	Old, New interface{}
+
+	// OldElement and NewElement are the element in the old and the new schema, which is nil if absent.
+	// It is one of *schema.Schema, *schema.SchemaAttribute and *schema.SchemaNestedBlock, depending on the Element.
+	OldElement, NewElement interface{}
+}
+
+// Address returns the full address of the changed element, e.g. "azurerm_foo.block.attr".
+func (c Change) Address() string {
+	var segs []string
+	if c.SchemaName != "" {
+		segs = append(segs, c.SchemaName)
+	}
+	segs = append(segs, c.Path...)
+	return strings.Join(segs, ".")
+}
+
+func (c Change) String() string {
+	var segs []string
+	if c.SchemaKind != 0 {
+		segs = append(segs, c.SchemaKind.String())
+	}
+	switch {
+	case c.Element == ElementKindSchema && c.SchemaName == "":
+		if len(segs) == 0 {
+			segs = append(segs, c.Element.String())
+		}
+	case c.Element == ElementKindSchema:
+		segs = append(segs, fmt.Sprintf("%q", c.SchemaName))
+	default:
+		segs = append(segs, c.Element.String(), fmt.Sprintf("%q", c.Address()))
+	}
+	subject := strings.Join(segs, " ")
+	if c.Kind != ChangeKindModified {
+		return fmt.Sprintf("%s %s", subject, c.Kind)
+	}
+	return fmt.Sprintf("%s: %s changed from %s to %s", subject, c.Property, formatValue(c.Old), formatValue(c.New))
+}
+
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case cty.Type:
+		if v == cty.NilType {
+			return "null"
+		}
+		return v.FriendlyName()
+	case cty.Value:
+		if v == cty.NilVal || v.IsNull() {
+			return "null"
+		}
+		b, err := ctyjson.Marshal(v, v.Type())
+		if err != nil {
+			return v.GoString()
+		}
+		return string(b)
+	case []string:
+		return fmt.Sprintf("%q", v)
+	case string:
+		return fmt.Sprintf("%q", v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// ProviderSchema compares the old and the new provider schema, and returns the changes of the provider,
+// resources and data sources, in a deterministic order.
+func ProviderSchema(old, new *schema.ProviderSchema) []Change {
+	if old == nil {
+		old = &schema.ProviderSchema{}
+	}
+	if new == nil {
+		new = &schema.ProviderSchema{}
+	}
+	var changes []Change
+	if old.Provider != nil || new.Provider != nil {
+		changes = append(changes, diffSchema(SchemaKindProvider, "", old.Provider, new.Provider)...)
+	}
+	changes = append(changes, diffSchemas(SchemaKindResource, old.ResourceSchemas, new.ResourceSchemas)...)
+	changes = append(changes, diffSchemas(SchemaKindDataSource, old.DataSourceSchemas, new.DataSourceSchemas)...)
+	changes = append(changes, diffSchemas(SchemaKindEphemeralResource, old.EphemeralResourceSchemas, new.EphemeralResourceSchemas)...)
+	return changes
+}
+
+// Schema compares the old and the new schema of a resource, data source or provider.
+// The schema kind and name of the returned changes are left unset.
+func Schema(old, new *schema.Schema) []Change {
+	return diffSchema(0, "", old, new)
+}
+
+func diffSchemas(kind SchemaKind, old, new map[string]*schema.Schema) []Change {
+	var changes []Change
+	for _, name := range unionKeys(old, new) {
+		changes = append(changes, diffSchema(kind, name, old[name], new[name])...)
+	}
+	return changes
+}
+
+func diffSchema(kind SchemaKind, name string, old, new *schema.Schema) []Change {
+	d := differ{kind: kind, name: name}
+	base := Change{SchemaKind: kind, SchemaName: name, Element: ElementKindSchema}
+	switch {
+	case old == nil && new == nil:
+		return nil
+	case old == nil:
+		base.Kind, base.NewElement = ChangeKindAdded, new
+		return []Change{base}
+	case new == nil:
+		base.Kind, base.OldElement = ChangeKindRemoved, old
+		return []Change{base}
+	}
+	base.OldElement, base.NewElement = old, new
+	d.property(base, PropertySchemaVersion, old.Version, new.Version)
+	d.property(base, PropertyDeprecationMessage, old.DeprecationMessage, new.DeprecationMessage)
+	d.block(nil, old.Block, new.Block)
+	return d.changes
+}
+
+type differ struct {
+	kind    SchemaKind
+	name    string
+	changes []Change
+}
+
+// property records a modified change if the old and new property values are different.
+func (d *differ) property(base Change, property string, old, new interface{}) {
+	if reflect.DeepEqual(old, new) {
+		return
+	}
+	base.Kind = ChangeKindModified
+	base.Property = property
+	base.Old, base.New = old, new
+	d.changes = append(d.changes, base)
+}
+
+func (d *differ) block(path []string, old, new *schema.SchemaBlock) {
+	if old == nil {
+		old = &schema.SchemaBlock{}
+	}
+	if new == nil {
+		new = &schema.SchemaBlock{}
+	}
+	d.attributes(path, old.Attributes, new.Attributes)
+
+	oldBlocks, newBlocks := old.BlockTypes.Map(), new.BlockTypes.Map()
+	for _, name := range unionKeys(oldBlocks, newBlocks) {
+		d.nestedBlock(appendPath(path, name), oldBlocks[name], newBlocks[name])
+	}
+}
+
+func (d *differ) attributes(path []string, old, new schema.SchemaAttributes) {
+	oldAttrs, newAttrs := old.Map(), new.Map()
+	for _, name := range unionKeys(oldAttrs, newAttrs) {
+		d.attribute(appendPath(path, name), oldAttrs[name], newAttrs[name])
+	}
+}
+
+func (d *differ) attribute(path []string, old, new *schema.SchemaAttribute) {
+	base := Change{SchemaKind: d.kind, SchemaName: d.name, Element: ElementKindAttribute, Path: path, OldElement: old, NewElement: new}
+	switch {
+	case old == nil:
+		base.Kind, base.OldElement = ChangeKindAdded, nil
+		d.changes = append(d.changes, base)
+		return
+	case new == nil:
+		base.Kind, base.NewElement = ChangeKindRemoved, nil
+		d.changes = append(d.changes, base)
+		return
+	}
+
+	if old.NestedType != nil && new.NestedType != nil {
+		d.property(base, PropertyNestingMode, old.NestedType.Nesting, new.NestedType.Nesting)
+	} else {
+		oldType, oldErr := old.ImpliedType()
+		newType, newErr := new.ImpliedType()
+		if (oldErr == nil) != (newErr == nil) || (oldErr == nil && !oldType.Equals(newType)) {
+			d.property(base, PropertyType, oldType, newType)
+		}
+	}
+	d.property(base, PropertyRequired, old.Required, new.Required)
+	d.property(base, PropertyOptional, old.Optional, new.Optional)
+	d.property(base, PropertyComputed, old.Computed, new.Computed)
+	d.property(base, PropertySensitive, old.Sensitive, new.Sensitive)
+	d.property(base, PropertyForceNew, boolValue(old.ForceNew), boolValue(new.ForceNew))
+	d.property(base, PropertyRequiresReplaceIf, boolValue(old.RequiresReplaceIf), boolValue(new.RequiresReplaceIf))
+	d.defaultValue(base, old, new)
+	d.property(base, PropertyDeprecationMessage, old.DeprecationMessage, new.DeprecationMessage)
+	d.property(base, PropertyConflictsWith, sortedStrings(old.ConflictsWith), sortedStrings(new.ConflictsWith))
+	d.property(base, PropertyExactlyOneOf, sortedStrings(old.ExactlyOneOf), sortedStrings(new.ExactlyOneOf))
+	d.property(base, PropertyAtLeastOneOf, sortedStrings(old.AtLeastOneOf), sortedStrings(new.AtLeastOneOf))
+	d.property(base, PropertyRequiredWith, sortedStrings(old.RequiredWith), sortedStrings(new.RequiredWith))
+
+	if old.NestedType != nil && new.NestedType != nil {
+		d.attributes(path, old.NestedType.Attributes, new.NestedType.Attributes)
+	}
+}
+
+func (d *differ) defaultValue(base Change, old, new *schema.SchemaAttribute) {
+	oldVal, oldErr := old.DefaultValue()
+	newVal, newErr := new.DefaultValue()
+	if oldErr != nil || newErr != nil {
+		// Fallback to compare the Go values, in case the default doesn't conform to the attribute type.
+		d.property(base, PropertyDefault, old.Default, new.Default)
+		return
+	}
+	if oldVal.RawEquals(newVal) || (oldVal.IsNull() && newVal.IsNull()) {
+		return
+	}
+	base.Kind = ChangeKindModified
+	base.Property = PropertyDefault
+	base.Old, base.New = oldVal, newVal
+	d.changes = append(d.changes, base)
+}
+
+func (d *differ) nestedBlock(path []string, old, new *schema.SchemaNestedBlock) {
+	base := Change{SchemaKind: d.kind, SchemaName: d.name, Element: ElementKindBlock, Path: path, OldElement: old, NewElement: new}
+	switch {
+	case old == nil:
+		base.Kind, base.OldElement = ChangeKindAdded, nil
+		d.changes = append(d.changes, base)
+		return
+	case new == nil:
+		base.Kind, base.NewElement = ChangeKindRemoved, nil
+		d.changes = append(d.changes, base)
+		return
+	}
+
+	d.property(base, PropertyNestingMode, old.Nesting, new.Nesting)
+	d.property(base, PropertyMinItems, old.MinItems, new.MinItems)
+	d.property(base, PropertyMaxItems, old.MaxItems, new.MaxItems)
+	d.property(base, PropertyRequired, boolValue(old.Required), boolValue(new.Required))
+	d.property(base, PropertyOptional, boolValue(old.Optional), boolValue(new.Optional))
+	d.property(base, PropertyComputed, boolValue(old.Computed), boolValue(new.Computed))
+	d.property(base, PropertyForceNew, boolValue(old.ForceNew), boolValue(new.ForceNew))
+	d.property(base, PropertyRequiresReplaceIf, boolValue(old.RequiresReplaceIf), boolValue(new.RequiresReplaceIf))
+	d.property(base, PropertyDeprecationMessage, old.DeprecationMessage, new.DeprecationMessage)
+	d.property(base, PropertyConflictsWith, sortedStrings(old.ConflictsWith), sortedStrings(new.ConflictsWith))
+	d.property(base, PropertyExactlyOneOf, sortedStrings(old.ExactlyOneOf), sortedStrings(new.ExactlyOneOf))
+	d.property(base, PropertyAtLeastOneOf, sortedStrings(old.AtLeastOneOf), sortedStrings(new.AtLeastOneOf))
+	d.property(base, PropertyRequiredWith, sortedStrings(old.RequiredWith), sortedStrings(new.RequiredWith))
+
+	d.block(path, old.Block, new.Block)
+}
+
+func appendPath(path []string, name string) []string {
+	out := make([]string, len(path), len(path)+1)
+	copy(out, path)
+	return append(out, name)
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
+
+// sortedStrings returns a sorted copy of the strings, which is nil for an empty input, so that the order and
+// the nil-ness doesn't make a difference.
+func sortedStrings(l []string) []string {
+	if len(l) == 0 {
+		return nil
+	}
+	out := append([]string{}, l...)
+	sort.Strings(out)
+	return out
+}
+
+func unionKeys[T any](a, b map[string]T) []string {
+	m := map[string]bool{}
+	for k := range a {
+		m[k] = true
+	}
+	for k := range b {
+		m[k] = true
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
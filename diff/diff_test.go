@@ -0,0 +1,156 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/magodo/tfpluginschema/diff"
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func ToPtr[T any](v T) *T {
+	return &v
+}
+
+func oldProviderSchema() *schema.ProviderSchema {
+	return &schema.ProviderSchema{
+		Provider: &schema.Schema{Block: &schema.SchemaBlock{}},
+		ResourceSchemas: map[string]*schema.Schema{
+			"foo_resource": {
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{Name: "name", Type: ToPtr(cty.String), Optional: true, ConflictsWith: []string{"b", "a"}},
+						{Name: "removed", Type: ToPtr(cty.String), Optional: true},
+						{Name: "size", Type: ToPtr(cty.Number), Optional: true, Default: 1},
+						{Name: "tags", Type: ToPtr(cty.Map(cty.String)), Optional: true},
+						{
+							Name: "nested",
+							NestedType: &schema.SchemaObject{
+								Nesting: schema.SchemaObjectNestingModeList,
+								Attributes: []*schema.SchemaAttribute{
+									{Name: "x", Type: ToPtr(cty.String), Optional: true},
+								},
+							},
+							Optional: true,
+						},
+					},
+					BlockTypes: []*schema.SchemaNestedBlock{
+						{
+							TypeName: "rule",
+							Nesting:  schema.SchemaNestedBlockNestingModeList,
+							MaxItems: 2,
+							Block: &schema.SchemaBlock{
+								Attributes: []*schema.SchemaAttribute{
+									{Name: "priority", Type: ToPtr(cty.Number), Required: true},
+								},
+							},
+						},
+					},
+				},
+			},
+			"foo_removed": {Block: &schema.SchemaBlock{}},
+		},
+	}
+}
+
+func newProviderSchema() *schema.ProviderSchema {
+	return &schema.ProviderSchema{
+		Provider: &schema.Schema{Block: &schema.SchemaBlock{}},
+		ResourceSchemas: map[string]*schema.Schema{
+			"foo_resource": {
+				Version: 1,
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{Name: "added", Type: ToPtr(cty.Bool), Computed: true},
+						{Name: "name", Type: ToPtr(cty.String), Required: true, ForceNew: ToPtr(true), ConflictsWith: []string{"a", "b"}},
+						{Name: "size", Type: ToPtr(cty.Number), Optional: true, Default: int64(2)},
+						{Name: "tags", Type: ToPtr(cty.Map(cty.String)), Optional: true, ForceNew: ToPtr(false)},
+						{
+							Name: "nested",
+							NestedType: &schema.SchemaObject{
+								Nesting: schema.SchemaObjectNestingModeSet,
+								Attributes: []*schema.SchemaAttribute{
+									{Name: "x", Type: ToPtr(cty.Number), Optional: true},
+								},
+							},
+							Optional: true,
+						},
+					},
+					BlockTypes: []*schema.SchemaNestedBlock{
+						{
+							TypeName: "rule",
+							Nesting:  schema.SchemaNestedBlockNestingModeSet,
+							MinItems: 1,
+							MaxItems: 2,
+							Block: &schema.SchemaBlock{
+								Attributes: []*schema.SchemaAttribute{
+									{Name: "priority", Type: ToPtr(cty.String), Required: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		DataSourceSchemas: map[string]*schema.Schema{
+			"foo_data": {Block: &schema.SchemaBlock{}},
+		},
+	}
+}
+
+func TestProviderSchema(t *testing.T) {
+	changes := diff.ProviderSchema(oldProviderSchema(), newProviderSchema())
+
+	var got []string
+	for _, change := range changes {
+		got = append(got, change.String())
+	}
+	require.Equal(t, []string{
+		`resource "foo_removed" removed`,
+		`resource "foo_resource": schema_version changed from 0 to 1`,
+		`resource attribute "foo_resource.added" added`,
+		`resource attribute "foo_resource.name": required changed from false to true`,
+		`resource attribute "foo_resource.name": optional changed from true to false`,
+		`resource attribute "foo_resource.name": force_new changed from false to true`,
+		`resource attribute "foo_resource.nested": nesting_mode changed from list to set`,
+		`resource attribute "foo_resource.nested.x": type changed from string to number`,
+		`resource attribute "foo_resource.removed" removed`,
+		`resource attribute "foo_resource.size": default changed from 1 to 2`,
+		`resource block "foo_resource.rule": nesting_mode changed from list to set`,
+		`resource block "foo_resource.rule": min_items changed from 0 to 1`,
+		`resource attribute "foo_resource.rule.priority": type changed from number to string`,
+		`data source "foo_data" added`,
+	}, got)
+
+	require.Equal(t, diff.Change{
+		Kind:       diff.ChangeKindModified,
+		SchemaKind: diff.SchemaKindResource,
+		SchemaName: "foo_resource",
+		Element:    diff.ElementKindBlock,
+		Path:       []string{"rule"},
+		Property:   diff.PropertyMinItems,
+		Old:        0,
+		New:        1,
+		OldElement: oldProviderSchema().ResourceSchemas["foo_resource"].Block.BlockTypes[0],
+		NewElement: newProviderSchema().ResourceSchemas["foo_resource"].Block.BlockTypes[0],
+	}, changes[11])
+}
+
+func TestProviderSchema_NoChange(t *testing.T) {
+	require.Empty(t, diff.ProviderSchema(oldProviderSchema(), oldProviderSchema()))
+	require.Empty(t, diff.ProviderSchema(nil, nil))
+}
+
+func TestSchema(t *testing.T) {
+	changes := diff.Schema(
+		&schema.Schema{Block: &schema.SchemaBlock{Attributes: []*schema.SchemaAttribute{{Name: "a", Type: ToPtr(cty.String), Optional: true}}}},
+		&schema.Schema{Block: &schema.SchemaBlock{Attributes: []*schema.SchemaAttribute{{Name: "a", Type: ToPtr(cty.List(cty.String)), Optional: true}}}},
+	)
+	require.Len(t, changes, 1)
+	require.Equal(t, `attribute "a": type changed from string to list of string`, changes[0].String())
+	require.Equal(t, []string{"a"}, changes[0].Path)
+	require.Equal(t, diff.PropertyType, changes[0].Property)
+	require.True(t, cty.String.Equals(changes[0].Old.(cty.Type)))
+	require.True(t, cty.List(cty.String).Equals(changes[0].New.(cty.Type)))
+}
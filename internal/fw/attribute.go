@@ -8,6 +8,8 @@ import (
 	"sort"
 
 	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	ephemeralschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	fwpath "github.com/hashicorp/terraform-plugin-framework/path"
 	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
@@ -17,7 +19,7 @@ import (
 	ctyjson "github.com/zclconf/go-cty/cty/json"
 )
 
-func ProviderSchemaAttribute(ctx context.Context, name string, path *tftypes.AttributePath, a providerschema.Attribute) (*schema.SchemaAttribute, error) {
+func ProviderSchemaAttribute(ctx context.Context, name string, path *tftypes.AttributePath, expr fwpath.Expression, a providerschema.Attribute) (*schema.SchemaAttribute, error) {
 	if !a.IsRequired() && !a.IsOptional() && !a.IsComputed() {
 		return nil, path.NewErrorf("must have Required, Optional, or Computed set")
 	}
@@ -29,6 +31,10 @@ func ProviderSchemaAttribute(ctx context.Context, name string, path *tftypes.Att
 		Computed:  a.IsComputed(),
 		Sensitive: a.IsSensitive(),
 	}
+	schemaAttribute.Description, schemaAttribute.DescriptionKind = description(a.GetDescription(), a.GetMarkdownDescription())
+	schemaAttribute.DeprecationMessage = a.GetDeprecationMessage()
+	schemaAttribute.Validators = attributeValidators(ctx, a)
+	addConstraints(expr, a, attributeConstraintFields(schemaAttribute))
 	tfType := a.GetType().TerraformType(ctx)
 	b, err := tfType.MarshalJSON()
 	if err != nil {
@@ -47,22 +53,26 @@ func ProviderSchemaAttribute(ctx context.Context, name string, path *tftypes.Att
 	}
 
 	object := &schema.SchemaObject{}
+	elemExpr := expr
 	nm := nestedAttribute.GetNestingMode()
 	switch fwschema.NestingMode(nm) {
 	case fwschema.NestingModeSingle:
 		object.Nesting = schema.SchemaObjectNestingModeSingle
 	case fwschema.NestingModeList:
 		object.Nesting = schema.SchemaObjectNestingModeList
+		elemExpr = expr.AtAnyListIndex()
 	case fwschema.NestingModeSet:
 		object.Nesting = schema.SchemaObjectNestingModeSet
+		elemExpr = expr.AtAnySetValue()
 	case fwschema.NestingModeMap:
 		object.Nesting = schema.SchemaObjectNestingModeMap
+		elemExpr = expr.AtAnyMapKey()
 	default:
 		return nil, path.NewErrorf("unrecognized nesting mode %v", nm)
 	}
 
 	for nestedName, nestedA := range nestedAttribute.GetNestedObject().GetAttributes() {
-		nestedSchemaAttribute, err := ProviderSchemaAttribute(ctx, nestedName, path.WithAttributeName(nestedName), nestedA)
+		nestedSchemaAttribute, err := ProviderSchemaAttribute(ctx, nestedName, path.WithAttributeName(nestedName), elemExpr.AtName(nestedName), nestedA)
 
 		if err != nil {
 			return nil, err
@@ -89,7 +99,7 @@ func ProviderSchemaAttribute(ctx context.Context, name string, path *tftypes.Att
 	return schemaAttribute, nil
 }
 
-func ResourceSchemaAttribute(ctx context.Context, name string, path *tftypes.AttributePath, a resourceschema.Attribute) (*schema.SchemaAttribute, error) {
+func ResourceSchemaAttribute(ctx context.Context, name string, path *tftypes.AttributePath, expr fwpath.Expression, a resourceschema.Attribute) (*schema.SchemaAttribute, error) {
 	if !a.IsRequired() && !a.IsOptional() && !a.IsComputed() {
 		return nil, path.NewErrorf("must have Required, Optional, or Computed set")
 	}
@@ -101,6 +111,18 @@ func ResourceSchemaAttribute(ctx context.Context, name string, path *tftypes.Att
 		Computed:  a.IsComputed(),
 		Sensitive: a.IsSensitive(),
 	}
+	schemaAttribute.Description, schemaAttribute.DescriptionKind = description(a.GetDescription(), a.GetMarkdownDescription())
+	schemaAttribute.DeprecationMessage = a.GetDeprecationMessage()
+	schemaAttribute.Validators = attributeValidators(ctx, a)
+	addConstraints(expr, a, attributeConstraintFields(schemaAttribute))
+	requiresReplace, requiresReplaceIf, modifiers := planModifiers(ctx, a)
+	if requiresReplace {
+		schemaAttribute.ForceNew = &requiresReplace
+	}
+	if requiresReplaceIf {
+		schemaAttribute.RequiresReplaceIf = &requiresReplaceIf
+	}
+	schemaAttribute.PlanModifiers = modifiers
 
 	switch a := a.(type) {
 	case resourceschema.BoolAttribute:
@@ -285,22 +307,26 @@ func ResourceSchemaAttribute(ctx context.Context, name string, path *tftypes.Att
 	}
 
 	object := &schema.SchemaObject{}
+	elemExpr := expr
 	nm := nestedAttribute.GetNestingMode()
 	switch fwschema.NestingMode(nm) {
 	case fwschema.NestingModeSingle:
 		object.Nesting = schema.SchemaObjectNestingModeSingle
 	case fwschema.NestingModeList:
 		object.Nesting = schema.SchemaObjectNestingModeList
+		elemExpr = expr.AtAnyListIndex()
 	case fwschema.NestingModeSet:
 		object.Nesting = schema.SchemaObjectNestingModeSet
+		elemExpr = expr.AtAnySetValue()
 	case fwschema.NestingModeMap:
 		object.Nesting = schema.SchemaObjectNestingModeMap
+		elemExpr = expr.AtAnyMapKey()
 	default:
 		return nil, path.NewErrorf("unrecognized nesting mode %v", nm)
 	}
 
 	for nestedName, nestedA := range nestedAttribute.GetNestedObject().GetAttributes() {
-		nestedSchemaAttribute, err := ResourceSchemaAttribute(ctx, nestedName, path.WithAttributeName(nestedName), nestedA)
+		nestedSchemaAttribute, err := ResourceSchemaAttribute(ctx, nestedName, path.WithAttributeName(nestedName), elemExpr.AtName(nestedName), nestedA)
 
 		if err != nil {
 			return nil, err
@@ -327,7 +353,7 @@ func ResourceSchemaAttribute(ctx context.Context, name string, path *tftypes.Att
 	return schemaAttribute, nil
 }
 
-func DatasourceSchemaAttribute(ctx context.Context, name string, path *tftypes.AttributePath, a datasourceschema.Attribute) (*schema.SchemaAttribute, error) {
+func DatasourceSchemaAttribute(ctx context.Context, name string, path *tftypes.AttributePath, expr fwpath.Expression, a datasourceschema.Attribute) (*schema.SchemaAttribute, error) {
 	if !a.IsRequired() && !a.IsOptional() && !a.IsComputed() {
 		return nil, path.NewErrorf("must have Required, Optional, or Computed set")
 	}
@@ -339,6 +365,10 @@ func DatasourceSchemaAttribute(ctx context.Context, name string, path *tftypes.A
 		Computed:  a.IsComputed(),
 		Sensitive: a.IsSensitive(),
 	}
+	schemaAttribute.Description, schemaAttribute.DescriptionKind = description(a.GetDescription(), a.GetMarkdownDescription())
+	schemaAttribute.DeprecationMessage = a.GetDeprecationMessage()
+	schemaAttribute.Validators = attributeValidators(ctx, a)
+	addConstraints(expr, a, attributeConstraintFields(schemaAttribute))
 	tfType := a.GetType().TerraformType(ctx)
 	b, err := tfType.MarshalJSON()
 	if err != nil {
@@ -357,22 +387,106 @@ func DatasourceSchemaAttribute(ctx context.Context, name string, path *tftypes.A
 	}
 
 	object := &schema.SchemaObject{}
+	elemExpr := expr
+	nm := nestedAttribute.GetNestingMode()
+	switch fwschema.NestingMode(nm) {
+	case fwschema.NestingModeSingle:
+		object.Nesting = schema.SchemaObjectNestingModeSingle
+	case fwschema.NestingModeList:
+		object.Nesting = schema.SchemaObjectNestingModeList
+		elemExpr = expr.AtAnyListIndex()
+	case fwschema.NestingModeSet:
+		object.Nesting = schema.SchemaObjectNestingModeSet
+		elemExpr = expr.AtAnySetValue()
+	case fwschema.NestingModeMap:
+		object.Nesting = schema.SchemaObjectNestingModeMap
+		elemExpr = expr.AtAnyMapKey()
+	default:
+		return nil, path.NewErrorf("unrecognized nesting mode %v", nm)
+	}
+
+	for nestedName, nestedA := range nestedAttribute.GetNestedObject().GetAttributes() {
+		nestedSchemaAttribute, err := DatasourceSchemaAttribute(ctx, nestedName, path.WithAttributeName(nestedName), elemExpr.AtName(nestedName), nestedA)
+
+		if err != nil {
+			return nil, err
+		}
+
+		object.Attributes = append(object.Attributes, nestedSchemaAttribute)
+	}
+
+	sort.Slice(object.Attributes, func(i, j int) bool {
+		if object.Attributes[i] == nil {
+			return true
+		}
+
+		if object.Attributes[j] == nil {
+			return false
+		}
+
+		return object.Attributes[i].Name < object.Attributes[j].Name
+	})
+
+	schemaAttribute.NestedType = object
+	schemaAttribute.Type = nil
+
+	return schemaAttribute, nil
+}
+
+func EphemeralSchemaAttribute(ctx context.Context, name string, path *tftypes.AttributePath, expr fwpath.Expression, a ephemeralschema.Attribute) (*schema.SchemaAttribute, error) {
+	if !a.IsRequired() && !a.IsOptional() && !a.IsComputed() {
+		return nil, path.NewErrorf("must have Required, Optional, or Computed set")
+	}
+
+	schemaAttribute := &schema.SchemaAttribute{
+		Name:      name,
+		Required:  a.IsRequired(),
+		Optional:  a.IsOptional(),
+		Computed:  a.IsComputed(),
+		Sensitive: a.IsSensitive(),
+	}
+	schemaAttribute.Description, schemaAttribute.DescriptionKind = description(a.GetDescription(), a.GetMarkdownDescription())
+	schemaAttribute.DeprecationMessage = a.GetDeprecationMessage()
+	schemaAttribute.Validators = attributeValidators(ctx, a)
+	addConstraints(expr, a, attributeConstraintFields(schemaAttribute))
+	tfType := a.GetType().TerraformType(ctx)
+	b, err := tfType.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("marshalling tftype: %v", err)
+	}
+	typ, err := ctyjson.UnmarshalType(b)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshalling to cty type: %v", err)
+	}
+	schemaAttribute.Type = &typ
+
+	nestedAttribute, ok := a.(ephemeralschema.NestedAttribute)
+
+	if !ok {
+		return schemaAttribute, nil
+	}
+
+	object := &schema.SchemaObject{}
+	elemExpr := expr
 	nm := nestedAttribute.GetNestingMode()
 	switch fwschema.NestingMode(nm) {
 	case fwschema.NestingModeSingle:
 		object.Nesting = schema.SchemaObjectNestingModeSingle
 	case fwschema.NestingModeList:
 		object.Nesting = schema.SchemaObjectNestingModeList
+		elemExpr = expr.AtAnyListIndex()
 	case fwschema.NestingModeSet:
 		object.Nesting = schema.SchemaObjectNestingModeSet
+		elemExpr = expr.AtAnySetValue()
 	case fwschema.NestingModeMap:
 		object.Nesting = schema.SchemaObjectNestingModeMap
+		elemExpr = expr.AtAnyMapKey()
 	default:
 		return nil, path.NewErrorf("unrecognized nesting mode %v", nm)
 	}
 
 	for nestedName, nestedA := range nestedAttribute.GetNestedObject().GetAttributes() {
-		nestedSchemaAttribute, err := DatasourceSchemaAttribute(ctx, nestedName, path.WithAttributeName(nestedName), nestedA)
+		nestedSchemaAttribute, err := EphemeralSchemaAttribute(ctx, nestedName, path.WithAttributeName(nestedName), elemExpr.AtName(nestedName), nestedA)
 
 		if err != nil {
 			return nil, err
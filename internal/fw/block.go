@@ -7,6 +7,8 @@ import (
 	"sort"
 
 	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	ephemeralschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	fwpath "github.com/hashicorp/terraform-plugin-framework/path"
 	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
@@ -14,18 +16,25 @@ import (
 	"github.com/magodo/tfpluginschema/schema"
 )
 
-func ProviderBlock(ctx context.Context, name string, path *tftypes.AttributePath, b providerschema.Block) (*schema.SchemaNestedBlock, error) {
+func ProviderBlock(ctx context.Context, name string, path *tftypes.AttributePath, expr fwpath.Expression, b providerschema.Block) (*schema.SchemaNestedBlock, error) {
 	schemaNestedBlock := &schema.SchemaNestedBlock{
 		Block:    &schema.SchemaBlock{},
 		TypeName: name,
 	}
+	schemaNestedBlock.Description, schemaNestedBlock.DescriptionKind = description(b.GetDescription(), b.GetMarkdownDescription())
+	schemaNestedBlock.DeprecationMessage = b.GetDeprecationMessage()
+	schemaNestedBlock.Validators = attributeValidators(ctx, b)
+	addConstraints(expr, b, blockConstraintFields(schemaNestedBlock))
 
+	elemExpr := expr
 	nm := b.GetNestingMode()
 	switch fwschema.BlockNestingMode(nm) {
 	case fwschema.BlockNestingModeList:
 		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeList
+		elemExpr = expr.AtAnyListIndex()
 	case fwschema.BlockNestingModeSet:
 		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeSet
+		elemExpr = expr.AtAnySetValue()
 	case fwschema.BlockNestingModeSingle:
 		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeSingle
 	default:
@@ -36,7 +45,7 @@ func ProviderBlock(ctx context.Context, name string, path *tftypes.AttributePath
 
 	for attrName, attr := range nestedBlockObject.GetAttributes() {
 		attrPath := path.WithAttributeName(attrName)
-		attrProto6, err := ProviderSchemaAttribute(ctx, attrName, attrPath, attr)
+		attrProto6, err := ProviderSchemaAttribute(ctx, attrName, attrPath, elemExpr.AtName(attrName), attr)
 
 		if err != nil {
 			return nil, err
@@ -47,7 +56,7 @@ func ProviderBlock(ctx context.Context, name string, path *tftypes.AttributePath
 
 	for blockName, block := range nestedBlockObject.GetBlocks() {
 		blockPath := path.WithAttributeName(blockName)
-		blockProto6, err := ProviderBlock(ctx, blockName, blockPath, block)
+		blockProto6, err := ProviderBlock(ctx, blockName, blockPath, elemExpr.AtName(blockName), block)
 
 		if err != nil {
 			return nil, err
@@ -83,18 +92,109 @@ func ProviderBlock(ctx context.Context, name string, path *tftypes.AttributePath
 	return schemaNestedBlock, nil
 }
 
-func ResourceBlock(ctx context.Context, name string, path *tftypes.AttributePath, b resourceschema.Block) (*schema.SchemaNestedBlock, error) {
+func ResourceBlock(ctx context.Context, name string, path *tftypes.AttributePath, expr fwpath.Expression, b resourceschema.Block) (*schema.SchemaNestedBlock, error) {
 	schemaNestedBlock := &schema.SchemaNestedBlock{
 		Block:    &schema.SchemaBlock{},
 		TypeName: name,
 	}
+	schemaNestedBlock.Description, schemaNestedBlock.DescriptionKind = description(b.GetDescription(), b.GetMarkdownDescription())
+	schemaNestedBlock.DeprecationMessage = b.GetDeprecationMessage()
+	schemaNestedBlock.Validators = attributeValidators(ctx, b)
+	addConstraints(expr, b, blockConstraintFields(schemaNestedBlock))
+	requiresReplace, requiresReplaceIf, modifiers := planModifiers(ctx, b)
+	if requiresReplace {
+		schemaNestedBlock.ForceNew = &requiresReplace
+	}
+	if requiresReplaceIf {
+		schemaNestedBlock.RequiresReplaceIf = &requiresReplaceIf
+	}
+	schemaNestedBlock.PlanModifiers = modifiers
+
+	elemExpr := expr
+	nm := b.GetNestingMode()
+	switch fwschema.BlockNestingMode(nm) {
+	case fwschema.BlockNestingModeList:
+		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeList
+		elemExpr = expr.AtAnyListIndex()
+	case fwschema.BlockNestingModeSet:
+		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeSet
+		elemExpr = expr.AtAnySetValue()
+	case fwschema.BlockNestingModeSingle:
+		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeSingle
+	default:
+		return nil, path.NewErrorf("unrecognized nesting mode %v", nm)
+	}
+
+	nestedBlockObject := b.GetNestedObject()
+
+	for attrName, attr := range nestedBlockObject.GetAttributes() {
+		attrPath := path.WithAttributeName(attrName)
+		attrProto6, err := ResourceSchemaAttribute(ctx, attrName, attrPath, elemExpr.AtName(attrName), attr)
+
+		if err != nil {
+			return nil, err
+		}
+
+		schemaNestedBlock.Block.Attributes = append(schemaNestedBlock.Block.Attributes, attrProto6)
+	}
+
+	for blockName, block := range nestedBlockObject.GetBlocks() {
+		blockPath := path.WithAttributeName(blockName)
+		blockProto6, err := ResourceBlock(ctx, blockName, blockPath, elemExpr.AtName(blockName), block)
+
+		if err != nil {
+			return nil, err
+		}
+
+		schemaNestedBlock.Block.BlockTypes = append(schemaNestedBlock.Block.BlockTypes, blockProto6)
+	}
+
+	sort.Slice(schemaNestedBlock.Block.Attributes, func(i, j int) bool {
+		if schemaNestedBlock.Block.Attributes[i] == nil {
+			return true
+		}
+
+		if schemaNestedBlock.Block.Attributes[j] == nil {
+			return false
+		}
+
+		return schemaNestedBlock.Block.Attributes[i].Name < schemaNestedBlock.Block.Attributes[j].Name
+	})
+
+	sort.Slice(schemaNestedBlock.Block.BlockTypes, func(i, j int) bool {
+		if schemaNestedBlock.Block.BlockTypes[i] == nil {
+			return true
+		}
+
+		if schemaNestedBlock.Block.BlockTypes[j] == nil {
+			return false
+		}
+
+		return schemaNestedBlock.Block.BlockTypes[i].TypeName < schemaNestedBlock.Block.BlockTypes[j].TypeName
+	})
+
+	return schemaNestedBlock, nil
+}
+
+func DatasourceBlock(ctx context.Context, name string, path *tftypes.AttributePath, expr fwpath.Expression, b datasourceschema.Block) (*schema.SchemaNestedBlock, error) {
+	schemaNestedBlock := &schema.SchemaNestedBlock{
+		Block:    &schema.SchemaBlock{},
+		TypeName: name,
+	}
+	schemaNestedBlock.Description, schemaNestedBlock.DescriptionKind = description(b.GetDescription(), b.GetMarkdownDescription())
+	schemaNestedBlock.DeprecationMessage = b.GetDeprecationMessage()
+	schemaNestedBlock.Validators = attributeValidators(ctx, b)
+	addConstraints(expr, b, blockConstraintFields(schemaNestedBlock))
 
+	elemExpr := expr
 	nm := b.GetNestingMode()
 	switch fwschema.BlockNestingMode(nm) {
 	case fwschema.BlockNestingModeList:
 		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeList
+		elemExpr = expr.AtAnyListIndex()
 	case fwschema.BlockNestingModeSet:
 		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeSet
+		elemExpr = expr.AtAnySetValue()
 	case fwschema.BlockNestingModeSingle:
 		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeSingle
 	default:
@@ -105,7 +205,7 @@ func ResourceBlock(ctx context.Context, name string, path *tftypes.AttributePath
 
 	for attrName, attr := range nestedBlockObject.GetAttributes() {
 		attrPath := path.WithAttributeName(attrName)
-		attrProto6, err := ResourceSchemaAttribute(ctx, attrName, attrPath, attr)
+		attrProto6, err := DatasourceSchemaAttribute(ctx, attrName, attrPath, elemExpr.AtName(attrName), attr)
 
 		if err != nil {
 			return nil, err
@@ -116,7 +216,7 @@ func ResourceBlock(ctx context.Context, name string, path *tftypes.AttributePath
 
 	for blockName, block := range nestedBlockObject.GetBlocks() {
 		blockPath := path.WithAttributeName(blockName)
-		blockProto6, err := ResourceBlock(ctx, blockName, blockPath, block)
+		blockProto6, err := DatasourceBlock(ctx, blockName, blockPath, elemExpr.AtName(blockName), block)
 
 		if err != nil {
 			return nil, err
@@ -152,18 +252,25 @@ func ResourceBlock(ctx context.Context, name string, path *tftypes.AttributePath
 	return schemaNestedBlock, nil
 }
 
-func DatasourceBlock(ctx context.Context, name string, path *tftypes.AttributePath, b datasourceschema.Block) (*schema.SchemaNestedBlock, error) {
+func EphemeralBlock(ctx context.Context, name string, path *tftypes.AttributePath, expr fwpath.Expression, b ephemeralschema.Block) (*schema.SchemaNestedBlock, error) {
 	schemaNestedBlock := &schema.SchemaNestedBlock{
 		Block:    &schema.SchemaBlock{},
 		TypeName: name,
 	}
+	schemaNestedBlock.Description, schemaNestedBlock.DescriptionKind = description(b.GetDescription(), b.GetMarkdownDescription())
+	schemaNestedBlock.DeprecationMessage = b.GetDeprecationMessage()
+	schemaNestedBlock.Validators = attributeValidators(ctx, b)
+	addConstraints(expr, b, blockConstraintFields(schemaNestedBlock))
 
+	elemExpr := expr
 	nm := b.GetNestingMode()
 	switch fwschema.BlockNestingMode(nm) {
 	case fwschema.BlockNestingModeList:
 		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeList
+		elemExpr = expr.AtAnyListIndex()
 	case fwschema.BlockNestingModeSet:
 		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeSet
+		elemExpr = expr.AtAnySetValue()
 	case fwschema.BlockNestingModeSingle:
 		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeSingle
 	default:
@@ -174,7 +281,7 @@ func DatasourceBlock(ctx context.Context, name string, path *tftypes.AttributePa
 
 	for attrName, attr := range nestedBlockObject.GetAttributes() {
 		attrPath := path.WithAttributeName(attrName)
-		attrProto6, err := DatasourceSchemaAttribute(ctx, attrName, attrPath, attr)
+		attrProto6, err := EphemeralSchemaAttribute(ctx, attrName, attrPath, elemExpr.AtName(attrName), attr)
 
 		if err != nil {
 			return nil, err
@@ -185,7 +292,7 @@ func DatasourceBlock(ctx context.Context, name string, path *tftypes.AttributePa
 
 	for blockName, block := range nestedBlockObject.GetBlocks() {
 		blockPath := path.WithAttributeName(blockName)
-		blockProto6, err := DatasourceBlock(ctx, blockName, blockPath, block)
+		blockProto6, err := EphemeralBlock(ctx, blockName, blockPath, elemExpr.AtName(blockName), block)
 
 		if err != nil {
 			return nil, err
@@ -0,0 +1,221 @@
+package fw
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/magodo/tfpluginschema/schema"
+)
+
+// constraintFields points to the SDKv2 style cross-attribute constraints of an attribute (or a nested block).
+type constraintFields struct {
+	conflictsWith *[]string
+	exactlyOneOf  *[]string
+	atLeastOneOf  *[]string
+	requiredWith  *[]string
+}
+
+func attributeConstraintFields(a *schema.SchemaAttribute) constraintFields {
+	return constraintFields{
+		conflictsWith: &a.ConflictsWith,
+		exactlyOneOf:  &a.ExactlyOneOf,
+		atLeastOneOf:  &a.AtLeastOneOf,
+		requiredWith:  &a.RequiredWith,
+	}
+}
+
+func blockConstraintFields(b *schema.SchemaNestedBlock) constraintFields {
+	return constraintFields{
+		conflictsWith: &b.ConflictsWith,
+		exactlyOneOf:  &b.ExactlyOneOf,
+		atLeastOneOf:  &b.AtLeastOneOf,
+		requiredWith:  &b.RequiredWith,
+	}
+}
+
+// add adds the keys to the constraint of the kind, skipping the existing ones.
+func (f constraintFields) add(kind schema.ValidatorKind, keys ...string) {
+	var l *[]string
+	switch kind {
+	case schema.ValidatorKindConflictsWith:
+		l = f.conflictsWith
+	case schema.ValidatorKindExactlyOneOf:
+		l = f.exactlyOneOf
+	case schema.ValidatorKindAtLeastOneOf:
+		l = f.atLeastOneOf
+	case schema.ValidatorKindAlsoRequires:
+		l = f.requiredWith
+	default:
+		return
+	}
+	for _, key := range keys {
+		if !contains(*l, key) {
+			*l = append(*l, key)
+		}
+	}
+}
+
+// addConstraints translates the cross-attribute validators of a FW attribute (or block), which is matched by the expr,
+// to the SDKv2 style constraints. As in SDKv2, the ExactlyOneOf and AtLeastOneOf include the attribute itself.
+func addConstraints(expr path.Expression, a interface{}, fields constraintFields) {
+	self, ok := sdkv2Key(expr)
+	if !ok {
+		return
+	}
+	for _, v := range validatorsOf(a) {
+		kind, exprs, ok := pathExpressions(v)
+		if !ok {
+			continue
+		}
+		keys := sdkv2Keys(expr.MergeExpressions(exprs...))
+		switch kind {
+		case schema.ValidatorKindExactlyOneOf, schema.ValidatorKindAtLeastOneOf:
+			fields.add(kind, self)
+			fields.add(kind, keys...)
+		default:
+			fields.add(kind, without(keys, self)...)
+		}
+	}
+}
+
+// addConfigConstraints translates the resource, data source or provider level cross-attribute config validators (e.g.
+// resourcevalidator.Conflicting) to the SDKv2 style constraints of the involved attributes (or blocks) of the block.
+func addConfigConstraints[T validator.Describer](blk *schema.SchemaBlock, validators []T) {
+	for _, v := range validators {
+		kind, exprs, ok := pathExpressions(v)
+		if !ok {
+			continue
+		}
+		keys := sdkv2Keys(exprs)
+		for _, key := range keys {
+			fields, ok := lookupConstraintFields(blk, key)
+			if !ok {
+				continue
+			}
+			switch kind {
+			case schema.ValidatorKindExactlyOneOf, schema.ValidatorKindAtLeastOneOf:
+				fields.add(kind, keys...)
+			default:
+				fields.add(kind, without(keys, key)...)
+			}
+		}
+	}
+}
+
+// lookupConstraintFields looks up the attribute (or nested block) in the block by the SDKv2 schema key.
+func lookupConstraintFields(blk *schema.SchemaBlock, key string) (constraintFields, bool) {
+	segs := strings.Split(key, ".")
+	attrs, blocks := blk.Attributes, blk.BlockTypes
+	for len(segs) != 0 {
+		name := segs[0]
+		segs = segs[1:]
+
+		if attr := findAttribute(attrs, name); attr != nil {
+			if len(segs) == 0 {
+				return attributeConstraintFields(attr), true
+			}
+			if attr.NestedType == nil {
+				return constraintFields{}, false
+			}
+			if attr.NestedType.Nesting != schema.SchemaObjectNestingModeSingle {
+				// Skip the element key
+				segs = segs[1:]
+			}
+			attrs, blocks = attr.NestedType.Attributes, nil
+			continue
+		}
+
+		if nb := findBlock(blocks, name); nb != nil {
+			if len(segs) == 0 {
+				return blockConstraintFields(nb), true
+			}
+			if nb.Nesting != schema.SchemaNestedBlockNestingModeSingle && nb.Nesting != schema.SchemaNestedBlockNestingModeGroup {
+				// Skip the element key
+				segs = segs[1:]
+			}
+			if nb.Block == nil {
+				return constraintFields{}, false
+			}
+			attrs, blocks = nb.Block.Attributes, nb.Block.BlockTypes
+			continue
+		}
+
+		return constraintFields{}, false
+	}
+	return constraintFields{}, false
+}
+
+// sdkv2Keys translates the path expressions to the SDKv2 schema keys, skipping the ones that can't be translated.
+func sdkv2Keys(exprs path.Expressions) []string {
+	var keys []string
+	for _, expr := range exprs {
+		if key, ok := sdkv2Key(expr); ok && !contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// sdkv2Key translates the path expression to the SDKv2 schema key (e.g. "block.0.attr").
+// As SDKv2 can only reference the first element of a list or set, any element is translated to the first one.
+func sdkv2Key(expr path.Expression) (string, bool) {
+	steps := expr.Resolve().Steps()
+	if len(steps) == 0 {
+		return "", false
+	}
+	segs := make([]string, 0, len(steps))
+	for _, step := range steps {
+		switch step := step.(type) {
+		case path.ExpressionStepAttributeNameExact:
+			segs = append(segs, string(step))
+		case path.ExpressionStepElementKeyIntAny, path.ExpressionStepElementKeyValueAny:
+			segs = append(segs, "0")
+		case path.ExpressionStepElementKeyIntExact:
+			segs = append(segs, strconv.FormatInt(int64(step), 10))
+		case path.ExpressionStepElementKeyStringExact:
+			segs = append(segs, string(step))
+		default:
+			return "", false
+		}
+	}
+	return strings.Join(segs, "."), true
+}
+
+func findAttribute(attrs []*schema.SchemaAttribute, name string) *schema.SchemaAttribute {
+	for _, attr := range attrs {
+		if attr.Name == name {
+			return attr
+		}
+	}
+	return nil
+}
+
+func findBlock(blocks []*schema.SchemaNestedBlock, name string) *schema.SchemaNestedBlock {
+	for _, nb := range blocks {
+		if nb.TypeName == name {
+			return nb
+		}
+	}
+	return nil
+}
+
+func contains(l []string, s string) bool {
+	for _, e := range l {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+func without(l []string, s string) []string {
+	var out []string
+	for _, e := range l {
+		if e != s {
+			out = append(out, e)
+		}
+	}
+	return out
+}
@@ -0,0 +1,163 @@
+package fw_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/magodo/tfpluginschema/internal/fw"
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/stretchr/testify/require"
+)
+
+type ConstraintsProvider struct{}
+
+func (*ConstraintsProvider) Configure(context.Context, provider.ConfigureRequest, *provider.ConfigureResponse) {
+}
+
+func (*ConstraintsProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (*ConstraintsProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "foo"
+}
+
+func (*ConstraintsProvider) Resources(context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		func() resource.Resource {
+			return &ConstraintsResource{}
+		},
+	}
+}
+
+func (*ConstraintsProvider) Schema(context.Context, provider.SchemaRequest, *provider.SchemaResponse) {
+}
+
+var _ resource.ResourceWithConfigValidators = &ConstraintsResource{}
+
+type ConstraintsResource struct{}
+
+func (*ConstraintsResource) Create(context.Context, resource.CreateRequest, *resource.CreateResponse) {
+}
+
+func (*ConstraintsResource) Delete(context.Context, resource.DeleteRequest, *resource.DeleteResponse) {
+}
+
+func (*ConstraintsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_resource"
+}
+
+func (*ConstraintsResource) Read(context.Context, resource.ReadRequest, *resource.ReadResponse) {
+}
+
+func (*ConstraintsResource) Update(context.Context, resource.UpdateRequest, *resource.UpdateResponse) {
+}
+
+func (*ConstraintsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceschema.Schema{
+		Attributes: map[string]resourceschema.Attribute{
+			"a": resourceschema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("b")),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("b")),
+				},
+			},
+			"b": resourceschema.StringAttribute{
+				Optional: true,
+			},
+			"c": resourceschema.StringAttribute{
+				Optional: true,
+			},
+			"d": resourceschema.StringAttribute{
+				Optional: true,
+			},
+			"nested": resourceschema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]resourceschema.Attribute{
+					"x": resourceschema.StringAttribute{
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("y")),
+						},
+					},
+					"y": resourceschema.StringAttribute{
+						Optional: true,
+					},
+				},
+			},
+		},
+		Blocks: map[string]resourceschema.Block{
+			"blk": resourceschema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.AtLeastOneOf(path.MatchRoot("c")),
+				},
+				NestedObject: resourceschema.NestedBlockObject{
+					Attributes: map[string]resourceschema.Attribute{
+						"x": resourceschema.StringAttribute{
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("y")),
+							},
+						},
+						"y": resourceschema.StringAttribute{
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (*ConstraintsResource) ConfigValidators(context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(path.MatchRoot("c"), path.MatchRoot("d")),
+		resourcevalidator.RequiredTogether(path.MatchRoot("blk").AtAnyListIndex().AtName("x"), path.MatchRoot("d")),
+	}
+}
+
+func TestConstraints(t *testing.T) {
+	ps, err := fw.FromProvider(&ConstraintsProvider{})
+	require.NoError(t, err)
+	blk := ps.ResourceSchemas["foo_resource"].Block
+
+	type constraints struct {
+		ConflictsWith []string
+		ExactlyOneOf  []string
+		AtLeastOneOf  []string
+		RequiredWith  []string
+	}
+	attrConstraints := func(attrs []*schema.SchemaAttribute, name string) constraints {
+		for _, attr := range attrs {
+			if attr.Name == name {
+				return constraints{attr.ConflictsWith, attr.ExactlyOneOf, attr.AtLeastOneOf, attr.RequiredWith}
+			}
+		}
+		t.Fatalf("attribute %q not found", name)
+		return constraints{}
+	}
+
+	require.Equal(t, constraints{ConflictsWith: []string{"b"}, ExactlyOneOf: []string{"a", "b"}}, attrConstraints(blk.Attributes, "a"))
+	require.Equal(t, constraints{}, attrConstraints(blk.Attributes, "b"))
+	require.Equal(t, constraints{ConflictsWith: []string{"d"}}, attrConstraints(blk.Attributes, "c"))
+	require.Equal(t, constraints{ConflictsWith: []string{"c"}, RequiredWith: []string{"blk.0.x"}}, attrConstraints(blk.Attributes, "d"))
+
+	nested := blk.Attributes[4]
+	require.Equal(t, "nested", nested.Name)
+	require.Equal(t, constraints{RequiredWith: []string{"nested.y"}}, attrConstraints(nested.NestedType.Attributes, "x"))
+
+	nb := blk.BlockTypes[0]
+	require.Equal(t, "blk", nb.TypeName)
+	require.Equal(t, []string{"blk", "c"}, nb.AtLeastOneOf)
+	require.Equal(t, constraints{ConflictsWith: []string{"blk.0.y"}, RequiredWith: []string{"d"}}, attrConstraints(nb.Block.Attributes, "x"))
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/magodo/tfpluginschema/schema"
@@ -32,15 +34,34 @@ func FromProvider(p provider.Provider) (*schema.ProviderSchema, error) {
 		datasources = append(datasources, df())
 	}
 
+	var ephemeralResources []ephemeral.EphemeralResource
+	if p, ok := p.(provider.ProviderWithEphemeralResources); ok {
+		for _, ef := range p.EphemeralResources(ctx) {
+			ephemeralResources = append(ephemeralResources, ef())
+		}
+	}
+
+	var functions []function.Function
+	if p, ok := p.(provider.ProviderWithFunctions); ok {
+		for _, ff := range p.Functions(ctx) {
+			functions = append(functions, ff())
+		}
+	}
+
 	providerSchema, err := ProviderSchema(ctx, providerSchemaResp.Schema)
 	if err != nil {
 		return nil, fmt.Errorf("converting provider schema: %v", err)
 	}
+	if p, ok := p.(provider.ProviderWithConfigValidators); ok {
+		addConfigConstraints(providerSchema.Block, p.ConfigValidators(ctx))
+	}
 
 	ret := &schema.ProviderSchema{
-		Provider:          providerSchema,
-		ResourceSchemas:   map[string]*schema.Schema{},
-		DataSourceSchemas: map[string]*schema.Schema{},
+		Provider:                 providerSchema,
+		ResourceSchemas:          map[string]*schema.Schema{},
+		DataSourceSchemas:        map[string]*schema.Schema{},
+		EphemeralResourceSchemas: map[string]*schema.Schema{},
+		Functions:                map[string]*schema.Function{},
 	}
 
 	for _, res := range resources {
@@ -56,6 +77,9 @@ func FromProvider(p provider.Provider) (*schema.ProviderSchema, error) {
 		if err != nil {
 			return nil, fmt.Errorf("converting resource schema (%s): %v", metadataResp.TypeName, err)
 		}
+		if res, ok := res.(resource.ResourceWithConfigValidators); ok {
+			addConfigConstraints(sch.Block, res.ConfigValidators(ctx))
+		}
 		ret.ResourceSchemas[metadataResp.TypeName] = sch
 	}
 	for _, ds := range datasources {
@@ -71,7 +95,43 @@ func FromProvider(p provider.Provider) (*schema.ProviderSchema, error) {
 		if err != nil {
 			return nil, fmt.Errorf("converting datasource schema (%s): %v", metadataResp.TypeName, err)
 		}
+		if ds, ok := ds.(datasource.DataSourceWithConfigValidators); ok {
+			addConfigConstraints(sch.Block, ds.ConfigValidators(ctx))
+		}
 		ret.DataSourceSchemas[metadataResp.TypeName] = sch
 	}
+	for _, er := range ephemeralResources {
+		var metadataResp ephemeral.MetadataResponse
+		er.Metadata(ctx, ephemeral.MetadataRequest{ProviderTypeName: providerMetadataResp.TypeName}, &metadataResp)
+
+		var schemaResp ephemeral.SchemaResponse
+		er.Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+		if schemaResp.Diagnostics.HasError() {
+			return nil, fmt.Errorf("getting ephemeral resource schema: %#v", schemaResp.Diagnostics)
+		}
+		sch, err := EphemeralResourceSchema(ctx, schemaResp.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("converting ephemeral resource schema (%s): %v", metadataResp.TypeName, err)
+		}
+		if er, ok := er.(ephemeral.EphemeralResourceWithConfigValidators); ok {
+			addConfigConstraints(sch.Block, er.ConfigValidators(ctx))
+		}
+		ret.EphemeralResourceSchemas[metadataResp.TypeName] = sch
+	}
+	for _, f := range functions {
+		var metadataResp function.MetadataResponse
+		f.Metadata(ctx, function.MetadataRequest{}, &metadataResp)
+
+		var definitionResp function.DefinitionResponse
+		f.Definition(ctx, function.DefinitionRequest{}, &definitionResp)
+		if definitionResp.Diagnostics.HasError() {
+			return nil, fmt.Errorf("getting function definition: %#v", definitionResp.Diagnostics)
+		}
+		fn, err := Function(ctx, definitionResp.Definition)
+		if err != nil {
+			return nil, fmt.Errorf("converting function (%s): %v", metadataResp.Name, err)
+		}
+		ret.Functions[metadataResp.Name] = fn
+	}
 	return ret, nil
 }
@@ -173,6 +173,8 @@ func (t *TestResource) Read(context.Context, resource.ReadRequest, *resource.Rea
 // Schema implements resource.Resource.
 func (t *TestResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = resourceschema.Schema{
+		MarkdownDescription: "A `resource`",
+		DeprecationMessage:  "Use `foo_new_resource` instead",
 		Attributes: map[string]resourceschema.Attribute{
 			"bool": resourceschema.BoolAttribute{
 				Required:  true,
@@ -182,11 +184,14 @@ func (t *TestResource) Schema(ctx context.Context, _ resource.SchemaRequest, res
 				Default:   booldefault.StaticBool(true),
 			},
 			"number": resourceschema.NumberAttribute{
-				Required: true,
+				Required:           true,
+				DeprecationMessage: "Use `string` instead",
 			},
 			"string": resourceschema.StringAttribute{
-				Required: true,
-				Default:  stringdefault.StaticString("foo"),
+				Required:            true,
+				Default:             stringdefault.StaticString("foo"),
+				Description:         "A string",
+				MarkdownDescription: "A `string`",
 			},
 			"list": resourceschema.ListAttribute{
 				Required:    true,
@@ -240,6 +245,7 @@ func (t *TestResource) Schema(ctx context.Context, _ resource.SchemaRequest, res
 				},
 			},
 			"list": resourceschema.ListNestedBlock{
+				MarkdownDescription: "A `list`",
 				NestedObject: resourceschema.NestedBlockObject{
 					Attributes: map[string]resourceschema.Attribute{
 						"string": resourceschema.StringAttribute{
@@ -249,6 +255,7 @@ func (t *TestResource) Schema(ctx context.Context, _ resource.SchemaRequest, res
 				},
 			},
 			"set": resourceschema.SetNestedBlock{
+				DeprecationMessage: "Use `list` instead",
 				NestedObject: resourceschema.NestedBlockObject{
 					Attributes: map[string]resourceschema.Attribute{
 						"string": resourceschema.StringAttribute{
@@ -283,6 +290,7 @@ func (t *TestDatasource) Read(context.Context, datasource.ReadRequest, *datasour
 // Schema implements datasource.DataSource.
 func (t *TestDatasource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = datasourceschema.Schema{
+		Description: "A datasource",
 		Attributes: map[string]datasourceschema.Attribute{
 			"bool": datasourceschema.BoolAttribute{
 				Required:  true,
@@ -294,7 +302,8 @@ func (t *TestDatasource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 				Required: true,
 			},
 			"string": datasourceschema.StringAttribute{
-				Required: true,
+				Required:    true,
+				Description: "A string",
 			},
 			"list": datasourceschema.ListAttribute{
 				Required:    true,
@@ -342,6 +351,7 @@ func (t *TestDatasource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 				},
 			},
 			"list": datasourceschema.ListNestedBlock{
+				Description: "A list",
 				NestedObject: datasourceschema.NestedBlockObject{
 					Attributes: map[string]datasourceschema.Attribute{
 						"string": datasourceschema.StringAttribute{
@@ -482,6 +492,9 @@ func TestFromProvider(t *testing.T) {
 		},
 		ResourceSchemas: map[string]*schema.Schema{
 			"foo_resource": {
+				Description:        "A `resource`",
+				DescriptionKind:    schema.StringKindMarkdown,
+				DeprecationMessage: "Use `foo_new_resource` instead",
 				Block: &schema.SchemaBlock{
 					Attributes: []*schema.SchemaAttribute{
 						{
@@ -521,9 +534,10 @@ func TestFromProvider(t *testing.T) {
 							Default:  map[string]interface{}{"a": "a"},
 						},
 						{
-							Name:     "number",
-							Required: true,
-							Type:     &cty.Number,
+							Name:               "number",
+							Required:           true,
+							Type:               &cty.Number,
+							DeprecationMessage: "Use `string` instead",
 						},
 						{
 							Name:     "object",
@@ -553,16 +567,20 @@ func TestFromProvider(t *testing.T) {
 							Default: map[string]interface{}{"a": "a"},
 						},
 						{
-							Name:     "string",
-							Required: true,
-							Type:     &cty.String,
-							Default:  "foo",
+							Name:            "string",
+							Required:        true,
+							Type:            &cty.String,
+							Default:         "foo",
+							Description:     "A `string`",
+							DescriptionKind: schema.StringKindMarkdown,
 						},
 					},
 					BlockTypes: []*schema.SchemaNestedBlock{
 						{
-							TypeName: "list",
-							Nesting:  schema.SchemaNestedBlockNestingModeList,
+							TypeName:        "list",
+							Nesting:         schema.SchemaNestedBlockNestingModeList,
+							Description:     "A `list`",
+							DescriptionKind: schema.StringKindMarkdown,
 							Block: &schema.SchemaBlock{
 								Attributes: []*schema.SchemaAttribute{
 									{
@@ -574,8 +592,9 @@ func TestFromProvider(t *testing.T) {
 							},
 						},
 						{
-							TypeName: "set",
-							Nesting:  schema.SchemaNestedBlockNestingModeSet,
+							TypeName:           "set",
+							Nesting:            schema.SchemaNestedBlockNestingModeSet,
+							DeprecationMessage: "Use `list` instead",
 							Block: &schema.SchemaBlock{
 								Attributes: []*schema.SchemaAttribute{
 									{
@@ -605,6 +624,8 @@ func TestFromProvider(t *testing.T) {
 		},
 		DataSourceSchemas: map[string]*schema.Schema{
 			"foo_resource": {
+				Description:     "A datasource",
+				DescriptionKind: schema.StringKindPlain,
 				Block: &schema.SchemaBlock{
 					Attributes: []*schema.SchemaAttribute{
 						{
@@ -669,15 +690,17 @@ func TestFromProvider(t *testing.T) {
 							},
 						},
 						{
-							Name:     "string",
-							Required: true,
-							Type:     &cty.String,
+							Name:        "string",
+							Required:    true,
+							Type:        &cty.String,
+							Description: "A string",
 						},
 					},
 					BlockTypes: []*schema.SchemaNestedBlock{
 						{
-							TypeName: "list",
-							Nesting:  schema.SchemaNestedBlockNestingModeList,
+							TypeName:    "list",
+							Nesting:     schema.SchemaNestedBlockNestingModeList,
+							Description: "A list",
 							Block: &schema.SchemaBlock{
 								Attributes: []*schema.SchemaAttribute{
 									{
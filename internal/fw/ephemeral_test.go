@@ -0,0 +1,151 @@
+package fw_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	ephemeralschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/magodo/tfpluginschema/internal/fw"
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var _ provider.ProviderWithEphemeralResources = &EphemeralProvider{}
+
+type EphemeralProvider struct{}
+
+func (*EphemeralProvider) Configure(context.Context, provider.ConfigureRequest, *provider.ConfigureResponse) {
+}
+
+func (*EphemeralProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (*EphemeralProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "foo"
+}
+
+func (*EphemeralProvider) Resources(context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (*EphemeralProvider) Schema(context.Context, provider.SchemaRequest, *provider.SchemaResponse) {
+}
+
+func (*EphemeralProvider) EphemeralResources(context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		func() ephemeral.EphemeralResource {
+			return &TestEphemeralResource{}
+		},
+	}
+}
+
+var _ ephemeral.EphemeralResource = &TestEphemeralResource{}
+
+type TestEphemeralResource struct{}
+
+func (*TestEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_token"
+}
+
+func (*TestEphemeralResource) Open(context.Context, ephemeral.OpenRequest, *ephemeral.OpenResponse) {
+}
+
+func (*TestEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = ephemeralschema.Schema{
+		Description: "A token",
+		Attributes: map[string]ephemeralschema.Attribute{
+			"scope": ephemeralschema.StringAttribute{
+				Required: true,
+			},
+			"token": ephemeralschema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+			"claims": ephemeralschema.ListNestedAttribute{
+				Optional: true,
+				NestedObject: ephemeralschema.NestedAttributeObject{
+					Attributes: map[string]ephemeralschema.Attribute{
+						"name": ephemeralschema.StringAttribute{
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]ephemeralschema.Block{
+			"retry": ephemeralschema.SingleNestedBlock{
+				Attributes: map[string]ephemeralschema.Attribute{
+					"attempts": ephemeralschema.Int64Attribute{
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestFromProviderEphemeralResources(t *testing.T) {
+	got, err := fw.FromProvider(&EphemeralProvider{})
+	require.NoError(t, err)
+
+	want := map[string]*schema.Schema{
+		"foo_token": {
+			Description: "A token",
+			Block: &schema.SchemaBlock{
+				Attributes: []*schema.SchemaAttribute{
+					{
+						Name:     "claims",
+						Optional: true,
+						NestedType: &schema.SchemaObject{
+							Attributes: []*schema.SchemaAttribute{
+								{
+									Name:     "name",
+									Type:     &cty.String,
+									Required: true,
+								},
+							},
+							Nesting: schema.SchemaObjectNestingModeList,
+						},
+					},
+					{
+						Name:     "scope",
+						Type:     &cty.String,
+						Required: true,
+					},
+					{
+						Name:      "token",
+						Type:      &cty.String,
+						Computed:  true,
+						Sensitive: true,
+					},
+				},
+				BlockTypes: []*schema.SchemaNestedBlock{
+					{
+						TypeName: "retry",
+						Nesting:  schema.SchemaNestedBlockNestingModeSingle,
+						Block: &schema.SchemaBlock{
+							Attributes: []*schema.SchemaAttribute{
+								{
+									Name:     "attempts",
+									Type:     &cty.Number,
+									Optional: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if !cmp.Equal(got.EphemeralResourceSchemas, want, equateEmpty, typeComparer) {
+		t.Error(cmp.Diff(got.EphemeralResourceSchemas, want, equateEmpty, typeComparer))
+	}
+}
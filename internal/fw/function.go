@@ -0,0 +1,85 @@
+package fw
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// Function converts the FW function definition. As documented by the FW, the unnamed parameters are named by "param"
+// with the 1-based position as the suffix, and the unnamed variadic parameter is named "varparam".
+func Function(ctx context.Context, d function.Definition) (*schema.Function, error) {
+	result := &schema.Function{
+		Summary:            d.Summary,
+		DeprecationMessage: d.DeprecationMessage,
+	}
+	result.Description, result.DescriptionKind = description(d.Description, d.MarkdownDescription)
+
+	for i, p := range d.Parameters {
+		param, err := FunctionParameter(ctx, p, "param"+strconv.Itoa(i+1))
+		if err != nil {
+			return nil, fmt.Errorf("converting parameter %d: %v", i, err)
+		}
+		result.Parameters = append(result.Parameters, param)
+	}
+	if d.VariadicParameter != nil {
+		param, err := FunctionParameter(ctx, d.VariadicParameter, "varparam")
+		if err != nil {
+			return nil, fmt.Errorf("converting variadic parameter: %v", err)
+		}
+		result.VariadicParameter = param
+	}
+
+	if isNil(d.Return) || d.Return.GetType() == nil {
+		return nil, fmt.Errorf("return type is undefined")
+	}
+	typ, err := functionType(ctx, d.Return.GetType())
+	if err != nil {
+		return nil, fmt.Errorf("converting return type: %v", err)
+	}
+	result.ReturnType = typ
+	return result, nil
+}
+
+// FunctionParameter converts the FW function parameter, using the defaultName if the parameter is unnamed.
+func FunctionParameter(ctx context.Context, p function.Parameter, defaultName string) (*schema.FunctionParameter, error) {
+	if isNil(p) {
+		return nil, fmt.Errorf("parameter is nil")
+	}
+	if p.GetType() == nil {
+		return nil, fmt.Errorf("type is undefined")
+	}
+	typ, err := functionType(ctx, p.GetType())
+	if err != nil {
+		return nil, err
+	}
+	result := &schema.FunctionParameter{
+		Name:               p.GetName(),
+		Type:               typ,
+		AllowNullValue:     p.GetAllowNullValue(),
+		AllowUnknownValues: p.GetAllowUnknownValues(),
+	}
+	if result.Name == "" {
+		result.Name = defaultName
+	}
+	result.Description, result.DescriptionKind = description(p.GetDescription(), p.GetMarkdownDescription())
+	return result, nil
+}
+
+func functionType(ctx context.Context, t attr.Type) (*cty.Type, error) {
+	b, err := t.TerraformType(ctx).MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("marshalling tftype: %v", err)
+	}
+	typ, err := ctyjson.UnmarshalType(b)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshalling to cty type: %v", err)
+	}
+	return &typ, nil
+}
@@ -0,0 +1,156 @@
+package fw_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/magodo/tfpluginschema/internal/fw"
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var _ provider.ProviderWithFunctions = &FunctionProvider{}
+
+type FunctionProvider struct{}
+
+func (*FunctionProvider) Configure(context.Context, provider.ConfigureRequest, *provider.ConfigureResponse) {
+}
+
+func (*FunctionProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (*FunctionProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "foo"
+}
+
+func (*FunctionProvider) Resources(context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (*FunctionProvider) Schema(context.Context, provider.SchemaRequest, *provider.SchemaResponse) {
+}
+
+func (*FunctionProvider) Functions(context.Context) []func() function.Function {
+	return []func() function.Function{
+		func() function.Function {
+			return &TestFunction{}
+		},
+	}
+}
+
+var _ function.Function = &TestFunction{}
+
+type TestFunction struct{}
+
+func (*TestFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_id"
+}
+
+func (*TestFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Parse an id",
+		MarkdownDescription: "Parse a **resource** id",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:           "id",
+				Description:    "The resource id",
+				AllowNullValue: true,
+			},
+			function.ListParameter{
+				ElementType: types.StringType,
+			},
+		},
+		VariadicParameter: function.Int64Parameter{
+			AllowUnknownValues: true,
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"name": types.StringType,
+			},
+		},
+	}
+}
+
+func (*TestFunction) Run(context.Context, function.RunRequest, *function.RunResponse) {
+}
+
+func TestFromProviderFunctions(t *testing.T) {
+	got, err := fw.FromProvider(&FunctionProvider{})
+	require.NoError(t, err)
+
+	want := map[string]*schema.Function{
+		"parse_id": {
+			Parameters: []*schema.FunctionParameter{
+				{
+					Name:           "id",
+					Type:           &cty.String,
+					AllowNullValue: true,
+					Description:    "The resource id",
+				},
+				{
+					Name: "param2",
+					Type: ToPtr(cty.List(cty.String)),
+				},
+			},
+			VariadicParameter: &schema.FunctionParameter{
+				Name:               "varparam",
+				Type:               &cty.Number,
+				AllowUnknownValues: true,
+			},
+			ReturnType:      ToPtr(cty.Object(map[string]cty.Type{"name": cty.String})),
+			Summary:         "Parse an id",
+			Description:     "Parse a **resource** id",
+			DescriptionKind: schema.StringKindMarkdown,
+		},
+	}
+
+	if !cmp.Equal(got.Functions, want, equateEmpty, typeComparer) {
+		t.Error(cmp.Diff(got.Functions, want, equateEmpty, typeComparer))
+	}
+}
+
+func TestFunction_Error(t *testing.T) {
+	ctx := context.Background()
+	ret := function.StringReturn{}
+
+	cases := map[string]struct {
+		def    function.Definition
+		expect string
+	}{
+		"nil parameter": {
+			def:    function.Definition{Parameters: []function.Parameter{function.StringParameter{}, nil}, Return: ret},
+			expect: "converting parameter 1: parameter is nil",
+		},
+		"nil pointer parameter": {
+			def:    function.Definition{Parameters: []function.Parameter{(*function.StringParameter)(nil)}, Return: ret},
+			expect: "converting parameter 0: parameter is nil",
+		},
+		"nil pointer variadic parameter": {
+			def:    function.Definition{VariadicParameter: (*function.Int64Parameter)(nil), Return: ret},
+			expect: "converting variadic parameter: parameter is nil",
+		},
+		"nil return": {
+			def:    function.Definition{},
+			expect: "return type is undefined",
+		},
+		"nil pointer return": {
+			def:    function.Definition{Return: (*function.StringReturn)(nil)},
+			expect: "return type is undefined",
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := fw.Function(ctx, tt.def)
+			require.EqualError(t, err, tt.expect)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package fw
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+)
+
+// requiresReplaceDescription is the description of the RequiresReplace plan modifiers (e.g. stringplanmodifier.RequiresReplace),
+// which is only used to distinguish them from the RequiresReplaceIf ones when the ifFunc can't be inspected.
+const requiresReplaceDescription = "If the value of this attribute changes, Terraform will destroy and recreate the resource."
+
+// planModifiers inspects the plan modifiers of a FW resource attribute (or block). It tells whether the resource is
+// unconditionally replaced (RequiresReplace) or conditionally replaced (e.g. RequiresReplaceIf, RequiresReplaceIfConfigured)
+// when the value changes, and returns the descriptions of the plan modifiers other than RequiresReplace.
+func planModifiers(ctx context.Context, a interface{}) (requiresReplace, requiresReplaceIf bool, descriptions []string) {
+	for _, m := range planModifiersOf(a) {
+		if isNil(m) {
+			continue
+		}
+		if isRequiresReplaceIf(m) {
+			if isRequiresReplace(ctx, m) {
+				requiresReplace = true
+				continue
+			}
+			requiresReplaceIf = true
+		}
+		descriptions = append(descriptions, m.Description(ctx))
+	}
+	return
+}
+
+// planModifiersOf returns the plan modifiers of a FW resource attribute (or block).
+func planModifiersOf(a interface{}) []planmodifier.Describer {
+	switch a := a.(type) {
+	case interface{ BoolPlanModifiers() []planmodifier.Bool }:
+		return planModifierDescribers(a.BoolPlanModifiers())
+	case interface{ Float32PlanModifiers() []planmodifier.Float32 }:
+		return planModifierDescribers(a.Float32PlanModifiers())
+	case interface{ Float64PlanModifiers() []planmodifier.Float64 }:
+		return planModifierDescribers(a.Float64PlanModifiers())
+	case interface{ Int32PlanModifiers() []planmodifier.Int32 }:
+		return planModifierDescribers(a.Int32PlanModifiers())
+	case interface{ Int64PlanModifiers() []planmodifier.Int64 }:
+		return planModifierDescribers(a.Int64PlanModifiers())
+	case interface{ NumberPlanModifiers() []planmodifier.Number }:
+		return planModifierDescribers(a.NumberPlanModifiers())
+	case interface{ StringPlanModifiers() []planmodifier.String }:
+		return planModifierDescribers(a.StringPlanModifiers())
+	case interface{ ListPlanModifiers() []planmodifier.List }:
+		return planModifierDescribers(a.ListPlanModifiers())
+	case interface{ MapPlanModifiers() []planmodifier.Map }:
+		return planModifierDescribers(a.MapPlanModifiers())
+	case interface{ SetPlanModifiers() []planmodifier.Set }:
+		return planModifierDescribers(a.SetPlanModifiers())
+	case interface{ ObjectPlanModifiers() []planmodifier.Object }:
+		return planModifierDescribers(a.ObjectPlanModifiers())
+	case interface{ DynamicPlanModifiers() []planmodifier.Dynamic }:
+		return planModifierDescribers(a.DynamicPlanModifiers())
+	default:
+		return nil
+	}
+}
+
+func planModifierDescribers[T planmodifier.Describer](modifiers []T) []planmodifier.Describer {
+	out := make([]planmodifier.Describer, 0, len(modifiers))
+	for _, m := range modifiers {
+		out = append(out, m)
+	}
+	return out
+}
+
+// isRequiresReplaceIf tells whether the plan modifier is built by one of the RequiresReplace* functions of the FW
+// plan modifier packages (e.g. stringplanmodifier).
+func isRequiresReplaceIf(m interface{}) bool {
+	typ := reflect.TypeOf(m)
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	pkgPath := typ.PkgPath()
+	return strings.HasPrefix(pkgPath, "github.com/hashicorp/terraform-plugin-framework/resource/schema/") &&
+		strings.HasSuffix(pkgPath, "planmodifier") &&
+		typ.Name() == "requiresReplaceIfModifier"
+}
+
+// isRequiresReplace tells whether the RequiresReplaceIf plan modifier is built by RequiresReplace, as both have the same
+// concrete type. RequiresReplace passes a function literal as the ifFunc, which is identified by its function name.
+func isRequiresReplace(ctx context.Context, m planmodifier.Describer) bool {
+	rv := reflect.ValueOf(m)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return false
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() == reflect.Struct {
+		if f := rv.FieldByName("ifFunc"); f.Kind() == reflect.Func && !f.IsNil() {
+			if fn := runtime.FuncForPC(f.Pointer()); fn != nil {
+				return strings.HasPrefix(fn.Name(), rv.Type().PkgPath()+".RequiresReplace.func")
+			}
+		}
+	}
+	return m.Description(ctx) == requiresReplaceDescription
+}
@@ -0,0 +1,78 @@
+package fw_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/magodo/tfpluginschema/internal/fw"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanModifiers(t *testing.T) {
+	ctx := context.Background()
+
+	attr, err := fw.ResourceSchemaAttribute(ctx, "foo", tftypes.NewAttributePath().WithAttributeName("foo"), path.MatchRoot("foo"), schema.StringAttribute{
+		Optional: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, ToPtr(true), attr.ForceNew)
+	require.Nil(t, attr.RequiresReplaceIf)
+	require.Equal(t, []string{"Once set, the value of this attribute in state will not change."}, attr.PlanModifiers)
+
+	attr, err = fw.ResourceSchemaAttribute(ctx, "bar", tftypes.NewAttributePath().WithAttributeName("bar"), path.MatchRoot("bar"), schema.StringAttribute{
+		Optional: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplaceIfConfigured(),
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, attr.ForceNew)
+	require.Equal(t, ToPtr(true), attr.RequiresReplaceIf)
+	require.Equal(t, []string{"If the value of this attribute is configured and changes, Terraform will destroy and recreate the resource."}, attr.PlanModifiers)
+
+	// A RequiresReplaceIf with the same description as RequiresReplace is still conditional.
+	attr, err = fw.ResourceSchemaAttribute(ctx, "qux", tftypes.NewAttributePath().WithAttributeName("qux"), path.MatchRoot("qux"), schema.StringAttribute{
+		Optional: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplaceIf(
+				func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+					resp.RequiresReplace = req.PlanValue.ValueString() != "keep"
+				},
+				"If the value of this attribute changes, Terraform will destroy and recreate the resource.",
+				"If the value of this attribute changes, Terraform will destroy and recreate the resource.",
+			),
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, attr.ForceNew)
+	require.Equal(t, ToPtr(true), attr.RequiresReplaceIf)
+	require.Equal(t, []string{"If the value of this attribute changes, Terraform will destroy and recreate the resource."}, attr.PlanModifiers)
+
+	attr, err = fw.ResourceSchemaAttribute(ctx, "baz", tftypes.NewAttributePath().WithAttributeName("baz"), path.MatchRoot("baz"), schema.StringAttribute{
+		Optional: true,
+	})
+	require.NoError(t, err)
+	require.Nil(t, attr.ForceNew)
+	require.Nil(t, attr.RequiresReplaceIf)
+	require.Empty(t, attr.PlanModifiers)
+
+	blk, err := fw.ResourceBlock(ctx, "blk", tftypes.NewAttributePath().WithAttributeName("blk"), path.MatchRoot("blk"), schema.ListNestedBlock{
+		PlanModifiers: []planmodifier.List{
+			listplanmodifier.RequiresReplace(),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, ToPtr(true), blk.ForceNew)
+	require.Nil(t, blk.RequiresReplaceIf)
+	require.Empty(t, blk.PlanModifiers)
+}
@@ -7,6 +7,8 @@ import (
 	"sort"
 
 	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	ephemeralschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
@@ -17,12 +19,14 @@ func ProviderSchema(ctx context.Context, s providerschema.Schema) (*schema.Schem
 	result := &schema.Schema{
 		Version: s.GetVersion(),
 	}
+	result.Description, result.DescriptionKind = description(s.GetDescription(), s.GetMarkdownDescription())
+	result.DeprecationMessage = s.GetDeprecationMessage()
 
 	var attrs []*schema.SchemaAttribute
 	var blocks []*schema.SchemaNestedBlock
 
 	for name, attr := range s.GetAttributes() {
-		a, err := ProviderSchemaAttribute(ctx, name, tftypes.NewAttributePath().WithAttributeName(name), attr)
+		a, err := ProviderSchemaAttribute(ctx, name, tftypes.NewAttributePath().WithAttributeName(name), path.MatchRoot(name), attr)
 
 		if err != nil {
 			return nil, err
@@ -32,7 +36,7 @@ func ProviderSchema(ctx context.Context, s providerschema.Schema) (*schema.Schem
 	}
 
 	for name, block := range s.GetBlocks() {
-		proto6, err := ProviderBlock(ctx, name, tftypes.NewAttributePath().WithAttributeName(name), block)
+		proto6, err := ProviderBlock(ctx, name, tftypes.NewAttributePath().WithAttributeName(name), path.MatchRoot(name), block)
 
 		if err != nil {
 			return nil, err
@@ -79,12 +83,14 @@ func ResourceSchema(ctx context.Context, s resourceschema.Schema) (*schema.Schem
 	result := &schema.Schema{
 		Version: s.GetVersion(),
 	}
+	result.Description, result.DescriptionKind = description(s.GetDescription(), s.GetMarkdownDescription())
+	result.DeprecationMessage = s.GetDeprecationMessage()
 
 	var attrs []*schema.SchemaAttribute
 	var blocks []*schema.SchemaNestedBlock
 
 	for name, attr := range s.GetAttributes() {
-		a, err := ResourceSchemaAttribute(ctx, name, tftypes.NewAttributePath().WithAttributeName(name), attr)
+		a, err := ResourceSchemaAttribute(ctx, name, tftypes.NewAttributePath().WithAttributeName(name), path.MatchRoot(name), attr)
 
 		if err != nil {
 			return nil, err
@@ -94,7 +100,7 @@ func ResourceSchema(ctx context.Context, s resourceschema.Schema) (*schema.Schem
 	}
 
 	for name, block := range s.GetBlocks() {
-		proto6, err := ResourceBlock(ctx, name, tftypes.NewAttributePath().WithAttributeName(name), block)
+		proto6, err := ResourceBlock(ctx, name, tftypes.NewAttributePath().WithAttributeName(name), path.MatchRoot(name), block)
 
 		if err != nil {
 			return nil, err
@@ -141,12 +147,14 @@ func DatasourceSchema(ctx context.Context, s datasourceschema.Schema) (*schema.S
 	result := &schema.Schema{
 		Version: s.GetVersion(),
 	}
+	result.Description, result.DescriptionKind = description(s.GetDescription(), s.GetMarkdownDescription())
+	result.DeprecationMessage = s.GetDeprecationMessage()
 
 	var attrs []*schema.SchemaAttribute
 	var blocks []*schema.SchemaNestedBlock
 
 	for name, attr := range s.GetAttributes() {
-		a, err := DatasourceSchemaAttribute(ctx, name, tftypes.NewAttributePath().WithAttributeName(name), attr)
+		a, err := DatasourceSchemaAttribute(ctx, name, tftypes.NewAttributePath().WithAttributeName(name), path.MatchRoot(name), attr)
 
 		if err != nil {
 			return nil, err
@@ -156,7 +164,80 @@ func DatasourceSchema(ctx context.Context, s datasourceschema.Schema) (*schema.S
 	}
 
 	for name, block := range s.GetBlocks() {
-		proto6, err := DatasourceBlock(ctx, name, tftypes.NewAttributePath().WithAttributeName(name), block)
+		proto6, err := DatasourceBlock(ctx, name, tftypes.NewAttributePath().WithAttributeName(name), path.MatchRoot(name), block)
+
+		if err != nil {
+			return nil, err
+		}
+
+		blocks = append(blocks, proto6)
+	}
+
+	sort.Slice(attrs, func(i, j int) bool {
+		if attrs[i] == nil {
+			return true
+		}
+
+		if attrs[j] == nil {
+			return false
+		}
+
+		return attrs[i].Name < attrs[j].Name
+	})
+
+	sort.Slice(blocks, func(i, j int) bool {
+		if blocks[i] == nil {
+			return true
+		}
+
+		if blocks[j] == nil {
+			return false
+		}
+
+		return blocks[i].TypeName < blocks[j].TypeName
+	})
+
+	result.Block = &schema.SchemaBlock{
+		// core doesn't do anything with version, as far as I can tell,
+		// so let's not set it.
+		Attributes: attrs,
+		BlockTypes: blocks,
+	}
+
+	return result, nil
+}
+
+// description returns the description together with its kind. The markdown description is preferred if it is set,
+// which is the same as how the framework converts it into the protocol schema.
+func description(desc, markdownDesc string) (string, schema.StringKind) {
+	if markdownDesc != "" {
+		return markdownDesc, schema.StringKindMarkdown
+	}
+	return desc, schema.StringKindPlain
+}
+
+func EphemeralResourceSchema(ctx context.Context, s ephemeralschema.Schema) (*schema.Schema, error) {
+	result := &schema.Schema{
+		Version: s.GetVersion(),
+	}
+	result.Description, result.DescriptionKind = description(s.GetDescription(), s.GetMarkdownDescription())
+	result.DeprecationMessage = s.GetDeprecationMessage()
+
+	var attrs []*schema.SchemaAttribute
+	var blocks []*schema.SchemaNestedBlock
+
+	for name, attr := range s.GetAttributes() {
+		a, err := EphemeralSchemaAttribute(ctx, name, tftypes.NewAttributePath().WithAttributeName(name), path.MatchRoot(name), attr)
+
+		if err != nil {
+			return nil, err
+		}
+
+		attrs = append(attrs, a)
+	}
+
+	for name, block := range s.GetBlocks() {
+		proto6, err := EphemeralBlock(ctx, name, tftypes.NewAttributePath().WithAttributeName(name), path.MatchRoot(name), block)
 
 		if err != nil {
 			return nil, err
@@ -0,0 +1,308 @@
+package fw
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/magodo/tfpluginschema/schema"
+)
+
+// validatorsModule is the module of terraform-plugin-framework-validators, whose validators are introspected by their concrete types.
+// The module is only imported in tests, the concrete types are matched by their package and type names instead, so that it works
+// with any version of the module that the provider is using.
+const validatorsModule = "github.com/hashicorp/terraform-plugin-framework-validators/"
+
+// validatorConverter extracts the structured constraint from the concrete validator value.
+// It returns false if the value doesn't have the expected structure.
+type validatorConverter func(v reflect.Value) (*schema.Validator, bool)
+
+// knownValidators are the known validators, keyed by "<package name>.<type name>".
+var knownValidators = map[string]validatorConverter{}
+
+func init() {
+	for _, pkg := range []string{"stringvalidator", "int32validator", "int64validator", "float32validator", "float64validator"} {
+		knownValidators[pkg+".oneOfValidator"] = valuesValidator(schema.ValidatorKindOneOf)
+		knownValidators[pkg+".noneOfValidator"] = valuesValidator(schema.ValidatorKindNoneOf)
+	}
+
+	for _, typ := range []string{"lengthBetweenValidator", "utf8LengthBetweenValidator"} {
+		knownValidators["stringvalidator."+typ] = rangeValidator(schema.ValidatorKindLength, "minLength", "maxLength")
+	}
+	for _, typ := range []string{"lengthAtLeastValidator", "utf8LengthAtLeastValidator"} {
+		knownValidators["stringvalidator."+typ] = rangeValidator(schema.ValidatorKindLength, "minLength", "")
+	}
+	for _, typ := range []string{"lengthAtMostValidator", "utf8LengthAtMostValidator"} {
+		knownValidators["stringvalidator."+typ] = rangeValidator(schema.ValidatorKindLength, "", "maxLength")
+	}
+	knownValidators["stringvalidator.regexMatchesValidator"] = regexMatchesValidator
+
+	for _, pkg := range []string{"int32validator", "int64validator", "float32validator", "float64validator"} {
+		knownValidators[pkg+".betweenValidator"] = rangeValidator(schema.ValidatorKindRange, "min", "max")
+		knownValidators[pkg+".atLeastValidator"] = rangeValidator(schema.ValidatorKindRange, "min", "")
+		knownValidators[pkg+".atMostValidator"] = rangeValidator(schema.ValidatorKindRange, "", "max")
+	}
+
+	for _, pkg := range []string{"listvalidator", "setvalidator", "mapvalidator"} {
+		knownValidators[pkg+".sizeBetweenValidator"] = rangeValidator(schema.ValidatorKindSize, "min", "max")
+		knownValidators[pkg+".sizeAtLeastValidator"] = rangeValidator(schema.ValidatorKindSize, "min", "")
+		knownValidators[pkg+".sizeAtMostValidator"] = rangeValidator(schema.ValidatorKindSize, "", "max")
+	}
+
+	for name, kind := range pathExpressionsValidators {
+		knownValidators[name] = pathExpressionsValidator(kind)
+	}
+}
+
+// pathExpressionsValidators are the known cross-attribute validators, keyed by "<package name>.<type name>".
+// The schemavalidator ones are used as attribute (or block) validators, while the configvalidator ones are used as
+// resource, data source or provider level config validators.
+var pathExpressionsValidators = map[string]schema.ValidatorKind{
+	"schemavalidator.ConflictsWithValidator":    schema.ValidatorKindConflictsWith,
+	"schemavalidator.ExactlyOneOfValidator":     schema.ValidatorKindExactlyOneOf,
+	"schemavalidator.AtLeastOneOfValidator":     schema.ValidatorKindAtLeastOneOf,
+	"schemavalidator.AlsoRequiresValidator":     schema.ValidatorKindAlsoRequires,
+	"configvalidator.ConflictingValidator":      schema.ValidatorKindConflictsWith,
+	"configvalidator.ExactlyOneOfValidator":     schema.ValidatorKindExactlyOneOf,
+	"configvalidator.AtLeastOneOfValidator":     schema.ValidatorKindAtLeastOneOf,
+	"configvalidator.RequiredTogetherValidator": schema.ValidatorKindAlsoRequires,
+}
+
+// Validators converts the FW validators to the structured validators. The unknown validators are kept as opaque
+// validators with only the description.
+func Validators[T validator.Describer](ctx context.Context, validators []T) []*schema.Validator {
+	var out []*schema.Validator
+	for _, v := range validators {
+		if isNil(v) {
+			continue
+		}
+		sv := convertValidator(v)
+		sv.Description = v.Description(ctx)
+		out = append(out, sv)
+	}
+	return out
+}
+
+// attributeValidators returns the structured validators of a FW attribute (or block).
+func attributeValidators(ctx context.Context, a interface{}) []*schema.Validator {
+	return Validators(ctx, validatorsOf(a))
+}
+
+// validatorsOf returns the validators of a FW attribute (or block).
+func validatorsOf(a interface{}) []validator.Describer {
+	switch a := a.(type) {
+	case interface{ BoolValidators() []validator.Bool }:
+		return describers(a.BoolValidators())
+	case interface{ Float32Validators() []validator.Float32 }:
+		return describers(a.Float32Validators())
+	case interface{ Float64Validators() []validator.Float64 }:
+		return describers(a.Float64Validators())
+	case interface{ Int32Validators() []validator.Int32 }:
+		return describers(a.Int32Validators())
+	case interface{ Int64Validators() []validator.Int64 }:
+		return describers(a.Int64Validators())
+	case interface{ NumberValidators() []validator.Number }:
+		return describers(a.NumberValidators())
+	case interface{ StringValidators() []validator.String }:
+		return describers(a.StringValidators())
+	case interface{ ListValidators() []validator.List }:
+		return describers(a.ListValidators())
+	case interface{ MapValidators() []validator.Map }:
+		return describers(a.MapValidators())
+	case interface{ SetValidators() []validator.Set }:
+		return describers(a.SetValidators())
+	case interface{ ObjectValidators() []validator.Object }:
+		return describers(a.ObjectValidators())
+	case interface{ DynamicValidators() []validator.Dynamic }:
+		return describers(a.DynamicValidators())
+	default:
+		return nil
+	}
+}
+
+func describers[T validator.Describer](validators []T) []validator.Describer {
+	out := make([]validator.Describer, 0, len(validators))
+	for _, v := range validators {
+		out = append(out, v)
+	}
+	return out
+}
+
+// knownTypeName returns the "<package name>.<type name>" of the validator, if it is defined in the validators module.
+func knownTypeName(v interface{}) (reflect.Value, string, bool) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return rv, "", false
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return rv, "", false
+	}
+	pkgPath := rv.Type().PkgPath()
+	if !strings.HasPrefix(pkgPath, validatorsModule) {
+		return rv, "", false
+	}
+	return rv, pkgPath[strings.LastIndex(pkgPath, "/")+1:] + "." + rv.Type().Name(), true
+}
+
+func convertValidator(v interface{}) *schema.Validator {
+	if rv, name, ok := knownTypeName(v); ok {
+		if conv, ok := knownValidators[name]; ok {
+			if sv, ok := conv(rv); ok {
+				return sv
+			}
+		}
+	}
+	return &schema.Validator{Kind: schema.ValidatorKindOpaque}
+}
+
+// valuesValidator converts the validators that hold the FW values (e.g. types.String) in the "values" field.
+func valuesValidator(kind schema.ValidatorKind) validatorConverter {
+	return func(v reflect.Value) (*schema.Validator, bool) {
+		values := v.FieldByName("values")
+		if values.Kind() != reflect.Slice {
+			return nil, false
+		}
+		sv := &schema.Validator{Kind: kind, Values: []string{}}
+		for i := 0; i < values.Len(); i++ {
+			// The FW values keep their primitive value in the "value" field.
+			elem := values.Index(i)
+			if elem.Kind() != reflect.Struct {
+				return nil, false
+			}
+			value := elem.FieldByName("value")
+			switch value.Kind() {
+			case reflect.String:
+				sv.Values = append(sv.Values, value.String())
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				sv.Values = append(sv.Values, strconv.FormatInt(value.Int(), 10))
+			case reflect.Pointer:
+				// The FW float values keep a *big.Float, which is formatted in the precision of the value type.
+				if value.Type() != reflect.TypeOf((*big.Float)(nil)) || value.IsNil() {
+					return nil, false
+				}
+				f := (*big.Float)(value.UnsafePointer())
+				if elem.Type().Name() == "Float32Value" {
+					f32, _ := f.Float32()
+					sv.Values = append(sv.Values, strconv.FormatFloat(float64(f32), 'g', -1, 32))
+				} else {
+					f64, _ := f.Float64()
+					sv.Values = append(sv.Values, strconv.FormatFloat(f64, 'g', -1, 64))
+				}
+			default:
+				return nil, false
+			}
+		}
+		return sv, true
+	}
+}
+
+// rangeValidator converts the validators that hold the bounds in the min and max fields. An empty field name means
+// the bound is absent.
+func rangeValidator(kind schema.ValidatorKind, minField, maxField string) validatorConverter {
+	return func(v reflect.Value) (*schema.Validator, bool) {
+		sv := &schema.Validator{Kind: kind}
+		if minField != "" {
+			n, ok := numberField(v, minField)
+			if !ok {
+				return nil, false
+			}
+			sv.Min = &n
+		}
+		if maxField != "" {
+			n, ok := numberField(v, maxField)
+			if !ok {
+				return nil, false
+			}
+			sv.Max = &n
+		}
+		return sv, true
+	}
+}
+
+func regexMatchesValidator(v reflect.Value) (*schema.Validator, bool) {
+	re := v.FieldByName("regexp")
+	if re.Kind() != reflect.Pointer || re.IsNil() {
+		return nil, false
+	}
+	// The methods can't be called on the unexported field, read the source expression of the *regexp.Regexp instead.
+	expr := re.Elem().FieldByName("expr")
+	if expr.Kind() != reflect.String {
+		return nil, false
+	}
+	return &schema.Validator{Kind: schema.ValidatorKindRegexMatches, Pattern: expr.String()}, true
+}
+
+func pathExpressionsValidator(kind schema.ValidatorKind) validatorConverter {
+	return func(v reflect.Value) (*schema.Validator, bool) {
+		exprs, ok := pathExpressionsField(v)
+		if !ok {
+			return nil, false
+		}
+		sv := &schema.Validator{Kind: kind}
+		for _, expr := range exprs {
+			sv.PathExpressions = append(sv.PathExpressions, expr.String())
+		}
+		return sv, true
+	}
+}
+
+// pathExpressions returns the kind and the path expressions of a known cross-attribute validator.
+func pathExpressions(v interface{}) (schema.ValidatorKind, path.Expressions, bool) {
+	rv, name, ok := knownTypeName(v)
+	if !ok {
+		return "", nil, false
+	}
+	kind, ok := pathExpressionsValidators[name]
+	if !ok {
+		return "", nil, false
+	}
+	exprs, ok := pathExpressionsField(rv)
+	if !ok {
+		return "", nil, false
+	}
+	return kind, exprs, true
+}
+
+func pathExpressionsField(v reflect.Value) (path.Expressions, bool) {
+	field := v.FieldByName("PathExpressions")
+	if !field.IsValid() || !field.CanInterface() {
+		return nil, false
+	}
+	exprs, ok := field.Interface().(path.Expressions)
+	return exprs, ok
+}
+
+func numberField(v reflect.Value, name string) (float64, bool) {
+	f := v.FieldByName(name)
+	switch f.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(f.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(f.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return f.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// isNil tells whether the interface value (e.g. a validator) is nil, including a typed nil, e.g. the validators-module
+// constructors return nil for invalid arguments.
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func:
+		return rv.IsNil()
+	}
+	return false
+}
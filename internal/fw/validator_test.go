@@ -0,0 +1,118 @@
+package fw_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/magodo/tfpluginschema/internal/fw"
+	tfschema "github.com/magodo/tfpluginschema/schema"
+	"github.com/stretchr/testify/require"
+)
+
+type customValidator struct{}
+
+func (customValidator) Description(context.Context) string {
+	return "custom validation"
+}
+
+func (customValidator) MarkdownDescription(context.Context) string {
+	return "custom validation"
+}
+
+func (customValidator) ValidateString(context.Context, validator.StringRequest, *validator.StringResponse) {
+}
+
+func TestValidators(t *testing.T) {
+	ctx := context.Background()
+
+	attr, err := fw.ResourceSchemaAttribute(ctx, "foo", tftypes.NewAttributePath().WithAttributeName("foo"), path.MatchRoot("foo"), schema.StringAttribute{
+		Optional: true,
+		Validators: []validator.String{
+			stringvalidator.OneOf("a", "b"),
+			stringvalidator.LengthBetween(1, 10),
+			stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z]+$`), "must be lower cased"),
+			stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("bar")),
+			customValidator{},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, attr.Validators, 5)
+	for _, v := range attr.Validators {
+		require.NotEmpty(t, v.Description)
+		v.Description = ""
+	}
+	require.Equal(t, []*tfschema.Validator{
+		{Kind: tfschema.ValidatorKindOneOf, Values: []string{"a", "b"}},
+		{Kind: tfschema.ValidatorKindLength, Min: ToPtr(float64(1)), Max: ToPtr(float64(10))},
+		{Kind: tfschema.ValidatorKindRegexMatches, Pattern: `^[a-z]+$`},
+		{Kind: tfschema.ValidatorKindConflictsWith, PathExpressions: []string{"<.bar"}},
+		{Kind: tfschema.ValidatorKindOpaque},
+	}, attr.Validators)
+
+	attr, err = fw.ResourceSchemaAttribute(ctx, "count", tftypes.NewAttributePath().WithAttributeName("count"), path.MatchRoot("count"), schema.Int64Attribute{
+		Optional: true,
+		Validators: []validator.Int64{
+			int64validator.Between(1, 5),
+			int64validator.OneOf(1, 3),
+			int64validator.ExactlyOneOf(path.MatchRoot("bar")),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*tfschema.Validator{
+		{Kind: tfschema.ValidatorKindRange, Description: "value must be between 1 and 5", Min: ToPtr(float64(1)), Max: ToPtr(float64(5))},
+		{Kind: tfschema.ValidatorKindOneOf, Description: `value must be one of: ["1" "3"]`, Values: []string{"1", "3"}},
+		{Kind: tfschema.ValidatorKindExactlyOneOf, Description: `Ensure that one and only one attribute from this collection is set: "[bar]"`, PathExpressions: []string{"bar"}},
+	}, attr.Validators)
+
+	attr, err = fw.ResourceSchemaAttribute(ctx, "ratio", tftypes.NewAttributePath().WithAttributeName("ratio"), path.MatchRoot("ratio"), schema.Float64Attribute{
+		Optional: true,
+		Validators: []validator.Float64{
+			float64validator.OneOf(0.1, 1),
+			float64validator.NoneOf(2.5),
+		},
+	})
+	require.NoError(t, err)
+	for _, v := range attr.Validators {
+		v.Description = ""
+	}
+	require.Equal(t, []*tfschema.Validator{
+		{Kind: tfschema.ValidatorKindOneOf, Values: []string{"0.1", "1"}},
+		{Kind: tfschema.ValidatorKindNoneOf, Values: []string{"2.5"}},
+	}, attr.Validators)
+
+	attr, err = fw.ResourceSchemaAttribute(ctx, "weight", tftypes.NewAttributePath().WithAttributeName("weight"), path.MatchRoot("weight"), schema.Float32Attribute{
+		Optional: true,
+		Validators: []validator.Float32{
+			float32validator.OneOf(0.1, 1),
+			float32validator.NoneOf(2.5),
+		},
+	})
+	require.NoError(t, err)
+	for _, v := range attr.Validators {
+		v.Description = ""
+	}
+	require.Equal(t, []*tfschema.Validator{
+		{Kind: tfschema.ValidatorKindOneOf, Values: []string{"0.1", "1"}},
+		{Kind: tfschema.ValidatorKindNoneOf, Values: []string{"2.5"}},
+	}, attr.Validators)
+
+	blk, err := fw.ResourceBlock(ctx, "blk", tftypes.NewAttributePath().WithAttributeName("blk"), path.MatchRoot("blk"), schema.ListNestedBlock{
+		Validators: []validator.List{
+			listvalidator.SizeAtMost(2),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*tfschema.Validator{
+		{Kind: tfschema.ValidatorKindSize, Description: "list must contain at most 2 elements", Max: ToPtr(float64(2))},
+	}, blk.Validators)
+}
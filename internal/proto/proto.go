@@ -0,0 +1,95 @@
+package proto
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+func ctyType(path *tftypes.AttributePath, t tftypes.Type) (*cty.Type, error) {
+	if t == nil {
+		return nil, nil
+	}
+	b, err := t.MarshalJSON()
+	if err != nil {
+		return nil, path.NewErrorf("marshalling tftype: %v", err)
+	}
+	typ, err := ctyjson.UnmarshalType(b)
+	if err != nil {
+		return nil, path.NewErrorf("unmarshalling to cty type: %v", err)
+	}
+	return &typ, nil
+}
+
+func deprecationMessage(deprecated bool) string {
+	if deprecated {
+		return schema.DefaultDeprecationMessage
+	}
+	return ""
+}
+
+func tfType(path *tftypes.AttributePath, typ cty.Type) (tftypes.Type, error) {
+	switch {
+	case typ == cty.NilType:
+		return nil, path.NewErrorf("type is nil")
+	case typ == cty.DynamicPseudoType:
+		return tftypes.DynamicPseudoType, nil
+	case typ == cty.String:
+		return tftypes.String, nil
+	case typ == cty.Number:
+		return tftypes.Number, nil
+	case typ == cty.Bool:
+		return tftypes.Bool, nil
+	case typ.IsListType():
+		et, err := tfType(path, typ.ElementType())
+		if err != nil {
+			return nil, err
+		}
+		return tftypes.List{ElementType: et}, nil
+	case typ.IsSetType():
+		et, err := tfType(path, typ.ElementType())
+		if err != nil {
+			return nil, err
+		}
+		return tftypes.Set{ElementType: et}, nil
+	case typ.IsMapType():
+		et, err := tfType(path, typ.ElementType())
+		if err != nil {
+			return nil, err
+		}
+		return tftypes.Map{ElementType: et}, nil
+	case typ.IsTupleType():
+		var ets []tftypes.Type
+		for _, ety := range typ.TupleElementTypes() {
+			et, err := tfType(path, ety)
+			if err != nil {
+				return nil, err
+			}
+			ets = append(ets, et)
+		}
+		return tftypes.Tuple{ElementTypes: ets}, nil
+	case typ.IsObjectType():
+		ret := tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}
+		for name, aty := range typ.AttributeTypes() {
+			at, err := tfType(path, aty)
+			if err != nil {
+				return nil, err
+			}
+			ret.AttributeTypes[name] = at
+			if typ.AttributeOptional(name) {
+				if ret.OptionalAttributes == nil {
+					ret.OptionalAttributes = map[string]struct{}{}
+				}
+				ret.OptionalAttributes[name] = struct{}{}
+			}
+		}
+		return ret, nil
+	default:
+		return nil, path.NewErrorf("unsupported cty type %s", typ.FriendlyName())
+	}
+}
+
+func deprecated(deprecationMessage string) bool {
+	return deprecationMessage != ""
+}
@@ -0,0 +1,472 @@
+package proto
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/magodo/tfpluginschema/schema"
+)
+
+func FromV5ProviderSchema(resp *tfprotov5.GetProviderSchemaResponse) (*schema.ProviderSchema, error) {
+	if resp == nil {
+		return nil, fmt.Errorf("getting provider schema: response is nil")
+	}
+	if err := v5DiagnosticsError(resp.Diagnostics); err != nil {
+		return nil, fmt.Errorf("getting provider schema: %v", err)
+	}
+
+	ret := &schema.ProviderSchema{
+		ResourceSchemas:          map[string]*schema.Schema{},
+		DataSourceSchemas:        map[string]*schema.Schema{},
+		EphemeralResourceSchemas: map[string]*schema.Schema{},
+		Functions:                map[string]*schema.Function{},
+	}
+
+	if resp.Provider != nil {
+		sch, err := FromV5Schema(resp.Provider)
+		if err != nil {
+			return nil, fmt.Errorf("converting provider schema: %v", err)
+		}
+		ret.Provider = sch
+	}
+	for name, res := range resp.ResourceSchemas {
+		sch, err := FromV5Schema(res)
+		if err != nil {
+			return nil, fmt.Errorf("converting resource schema (%s): %v", name, err)
+		}
+		ret.ResourceSchemas[name] = sch
+	}
+	for name, ds := range resp.DataSourceSchemas {
+		sch, err := FromV5Schema(ds)
+		if err != nil {
+			return nil, fmt.Errorf("converting datasource schema (%s): %v", name, err)
+		}
+		ret.DataSourceSchemas[name] = sch
+	}
+	for name, er := range resp.EphemeralResourceSchemas {
+		sch, err := FromV5Schema(er)
+		if err != nil {
+			return nil, fmt.Errorf("converting ephemeral resource schema (%s): %v", name, err)
+		}
+		ret.EphemeralResourceSchemas[name] = sch
+	}
+	for name, f := range resp.Functions {
+		fn, err := fromV5Function(f)
+		if err != nil {
+			return nil, fmt.Errorf("converting function (%s): %v", name, err)
+		}
+		ret.Functions[name] = fn
+	}
+	return ret, nil
+}
+
+// FromV5Functions converts the functions of the GetFunctions response.
+func FromV5Functions(resp *tfprotov5.GetFunctionsResponse) (map[string]*schema.Function, error) {
+	if resp == nil {
+		return nil, fmt.Errorf("getting functions: response is nil")
+	}
+	if err := v5DiagnosticsError(resp.Diagnostics); err != nil {
+		return nil, fmt.Errorf("getting functions: %v", err)
+	}
+	ret := map[string]*schema.Function{}
+	for name, f := range resp.Functions {
+		fn, err := fromV5Function(f)
+		if err != nil {
+			return nil, fmt.Errorf("converting function (%s): %v", name, err)
+		}
+		ret[name] = fn
+	}
+	return ret, nil
+}
+
+func fromV5Function(f *tfprotov5.Function) (*schema.Function, error) {
+	if f == nil {
+		return nil, fmt.Errorf("function is nil")
+	}
+	ret := &schema.Function{
+		Summary:            f.Summary,
+		Description:        f.Description,
+		DescriptionKind:    schema.StringKind(f.DescriptionKind),
+		DeprecationMessage: f.DeprecationMessage,
+	}
+	for i, p := range f.Parameters {
+		param, err := fromV5FunctionParameter(p)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %d: %v", i, err)
+		}
+		ret.Parameters = append(ret.Parameters, param)
+	}
+	if f.VariadicParameter != nil {
+		param, err := fromV5FunctionParameter(f.VariadicParameter)
+		if err != nil {
+			return nil, fmt.Errorf("variadic parameter: %v", err)
+		}
+		ret.VariadicParameter = param
+	}
+	if f.Return == nil || f.Return.Type == nil {
+		return nil, fmt.Errorf("return: must have Type set")
+	}
+	typ, err := ctyType(tftypes.NewAttributePath(), f.Return.Type)
+	if err != nil {
+		return nil, fmt.Errorf("return: %v", err)
+	}
+	ret.ReturnType = typ
+	return ret, nil
+}
+
+func fromV5FunctionParameter(p *tfprotov5.FunctionParameter) (*schema.FunctionParameter, error) {
+	if p == nil {
+		return nil, fmt.Errorf("parameter is nil")
+	}
+	if p.Type == nil {
+		return nil, fmt.Errorf("must have Type set")
+	}
+	typ, err := ctyType(tftypes.NewAttributePath(), p.Type)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.FunctionParameter{
+		Name:               p.Name,
+		Type:               typ,
+		AllowNullValue:     p.AllowNullValue,
+		AllowUnknownValues: p.AllowUnknownValues,
+		Description:        p.Description,
+		DescriptionKind:    schema.StringKind(p.DescriptionKind),
+	}, nil
+}
+
+func FromV5Schema(s *tfprotov5.Schema) (*schema.Schema, error) {
+	if s == nil {
+		return nil, fmt.Errorf("schema is nil")
+	}
+	result := &schema.Schema{
+		Version: s.Version,
+	}
+	if s.Block == nil {
+		return result, nil
+	}
+	result.Description = s.Block.Description
+	result.DescriptionKind = schema.StringKind(s.Block.DescriptionKind)
+	result.DeprecationMessage = deprecationMessage(s.Block.Deprecated)
+
+	block, err := fromV5Block(tftypes.NewAttributePath(), s.Block)
+	if err != nil {
+		return nil, err
+	}
+	result.Block = block
+	return result, nil
+}
+
+func fromV5Block(path *tftypes.AttributePath, b *tfprotov5.SchemaBlock) (*schema.SchemaBlock, error) {
+	result := &schema.SchemaBlock{}
+
+	for _, attr := range b.Attributes {
+		a, err := fromV5Attribute(path.WithAttributeName(attr.Name), attr)
+		if err != nil {
+			return nil, err
+		}
+		result.Attributes = append(result.Attributes, a)
+	}
+
+	for _, block := range b.BlockTypes {
+		nb, err := fromV5NestedBlock(path.WithAttributeName(block.TypeName), block)
+		if err != nil {
+			return nil, err
+		}
+		result.BlockTypes = append(result.BlockTypes, nb)
+	}
+
+	sort.Slice(result.Attributes, func(i, j int) bool {
+		return result.Attributes[i].Name < result.Attributes[j].Name
+	})
+
+	sort.Slice(result.BlockTypes, func(i, j int) bool {
+		return result.BlockTypes[i].TypeName < result.BlockTypes[j].TypeName
+	})
+
+	return result, nil
+}
+
+func fromV5Attribute(path *tftypes.AttributePath, a *tfprotov5.SchemaAttribute) (*schema.SchemaAttribute, error) {
+	if a.Type == nil {
+		return nil, path.NewErrorf("must have Type set")
+	}
+	typ, err := ctyType(path, a.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.SchemaAttribute{
+		Name:               a.Name,
+		Type:               typ,
+		Required:           a.Required,
+		Optional:           a.Optional,
+		Computed:           a.Computed,
+		Sensitive:          a.Sensitive,
+		Description:        a.Description,
+		DescriptionKind:    schema.StringKind(a.DescriptionKind),
+		DeprecationMessage: deprecationMessage(a.Deprecated),
+	}, nil
+}
+
+func fromV5NestedBlock(path *tftypes.AttributePath, b *tfprotov5.SchemaNestedBlock) (*schema.SchemaNestedBlock, error) {
+	schemaNestedBlock := &schema.SchemaNestedBlock{
+		TypeName: b.TypeName,
+		MinItems: int(b.MinItems),
+		MaxItems: int(b.MaxItems),
+	}
+
+	switch nm := b.Nesting; nm {
+	case tfprotov5.SchemaNestedBlockNestingModeSingle:
+		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeSingle
+	case tfprotov5.SchemaNestedBlockNestingModeList:
+		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeList
+	case tfprotov5.SchemaNestedBlockNestingModeSet:
+		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeSet
+	case tfprotov5.SchemaNestedBlockNestingModeMap:
+		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeMap
+	case tfprotov5.SchemaNestedBlockNestingModeGroup:
+		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeGroup
+	default:
+		return nil, path.NewErrorf("unrecognized nesting mode %v", nm)
+	}
+
+	if b.Block == nil {
+		schemaNestedBlock.Block = &schema.SchemaBlock{}
+		return schemaNestedBlock, nil
+	}
+
+	schemaNestedBlock.Description = b.Block.Description
+	schemaNestedBlock.DescriptionKind = schema.StringKind(b.Block.DescriptionKind)
+	schemaNestedBlock.DeprecationMessage = deprecationMessage(b.Block.Deprecated)
+
+	block, err := fromV5Block(path, b.Block)
+	if err != nil {
+		return nil, err
+	}
+	schemaNestedBlock.Block = block
+
+	return schemaNestedBlock, nil
+}
+
+func v5DiagnosticsError(diags []*tfprotov5.Diagnostic) error {
+	var errs []error
+	for _, diag := range diags {
+		if diag == nil || diag.Severity != tfprotov5.DiagnosticSeverityError {
+			continue
+		}
+		errs = append(errs, fmt.Errorf("%s: %s", diag.Summary, diag.Detail))
+	}
+	return errors.Join(errs...)
+}
+
+func ToV5ProviderSchema(ps *schema.ProviderSchema) (*tfprotov5.GetProviderSchemaResponse, error) {
+	if ps == nil {
+		return nil, fmt.Errorf("provider schema is nil")
+	}
+	ret := &tfprotov5.GetProviderSchemaResponse{
+		ResourceSchemas:          map[string]*tfprotov5.Schema{},
+		DataSourceSchemas:        map[string]*tfprotov5.Schema{},
+		EphemeralResourceSchemas: map[string]*tfprotov5.Schema{},
+		Functions:                map[string]*tfprotov5.Function{},
+	}
+
+	if ps.Provider != nil {
+		sch, err := ToV5Schema(ps.Provider)
+		if err != nil {
+			return nil, fmt.Errorf("converting provider schema: %v", err)
+		}
+		ret.Provider = sch
+	}
+	for name, res := range ps.ResourceSchemas {
+		sch, err := ToV5Schema(res)
+		if err != nil {
+			return nil, fmt.Errorf("converting resource schema (%s): %v", name, err)
+		}
+		ret.ResourceSchemas[name] = sch
+	}
+	for name, ds := range ps.DataSourceSchemas {
+		sch, err := ToV5Schema(ds)
+		if err != nil {
+			return nil, fmt.Errorf("converting datasource schema (%s): %v", name, err)
+		}
+		ret.DataSourceSchemas[name] = sch
+	}
+	for name, er := range ps.EphemeralResourceSchemas {
+		sch, err := ToV5Schema(er)
+		if err != nil {
+			return nil, fmt.Errorf("converting ephemeral resource schema (%s): %v", name, err)
+		}
+		ret.EphemeralResourceSchemas[name] = sch
+	}
+	for name, f := range ps.Functions {
+		fn, err := toV5Function(f)
+		if err != nil {
+			return nil, fmt.Errorf("converting function (%s): %v", name, err)
+		}
+		ret.Functions[name] = fn
+	}
+	return ret, nil
+}
+
+func toV5Function(f *schema.Function) (*tfprotov5.Function, error) {
+	ret := &tfprotov5.Function{
+		Parameters:         []*tfprotov5.FunctionParameter{},
+		Summary:            f.Summary,
+		Description:        f.Description,
+		DescriptionKind:    tfprotov5.StringKind(f.DescriptionKind),
+		DeprecationMessage: f.DeprecationMessage,
+	}
+	for i, p := range f.Parameters {
+		param, err := toV5FunctionParameter(p)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %d: %v", i, err)
+		}
+		ret.Parameters = append(ret.Parameters, param)
+	}
+	if f.VariadicParameter != nil {
+		param, err := toV5FunctionParameter(f.VariadicParameter)
+		if err != nil {
+			return nil, fmt.Errorf("variadic parameter: %v", err)
+		}
+		ret.VariadicParameter = param
+	}
+	if f.ReturnType == nil {
+		return nil, fmt.Errorf("return type is nil")
+	}
+	typ, err := tfType(tftypes.NewAttributePath(), *f.ReturnType)
+	if err != nil {
+		return nil, fmt.Errorf("return: %v", err)
+	}
+	ret.Return = &tfprotov5.FunctionReturn{Type: typ}
+	return ret, nil
+}
+
+func toV5FunctionParameter(p *schema.FunctionParameter) (*tfprotov5.FunctionParameter, error) {
+	if p.Type == nil {
+		return nil, fmt.Errorf("type is nil")
+	}
+	typ, err := tfType(tftypes.NewAttributePath(), *p.Type)
+	if err != nil {
+		return nil, err
+	}
+	return &tfprotov5.FunctionParameter{
+		Name:               p.Name,
+		Type:               typ,
+		AllowNullValue:     p.AllowNullValue,
+		AllowUnknownValues: p.AllowUnknownValues,
+		Description:        p.Description,
+		DescriptionKind:    tfprotov5.StringKind(p.DescriptionKind),
+	}, nil
+}
+
+func ToV5Schema(s *schema.Schema) (*tfprotov5.Schema, error) {
+	if s == nil {
+		return nil, fmt.Errorf("schema is nil")
+	}
+	block, err := ToV5Block(s.Block)
+	if err != nil {
+		return nil, err
+	}
+	block.Description = s.Description
+	block.DescriptionKind = tfprotov5.StringKind(s.DescriptionKind)
+	block.Deprecated = deprecated(s.DeprecationMessage)
+
+	return &tfprotov5.Schema{
+		Version: s.Version,
+		Block:   block,
+	}, nil
+}
+
+func ToV5Block(b *schema.SchemaBlock) (*tfprotov5.SchemaBlock, error) {
+	return toV5Block(tftypes.NewAttributePath(), b)
+}
+
+func toV5Block(path *tftypes.AttributePath, b *schema.SchemaBlock) (*tfprotov5.SchemaBlock, error) {
+	result := &tfprotov5.SchemaBlock{}
+	if b == nil {
+		return result, nil
+	}
+
+	for _, attr := range b.Attributes {
+		a, err := toV5Attribute(path.WithAttributeName(attr.Name), attr)
+		if err != nil {
+			return nil, err
+		}
+		result.Attributes = append(result.Attributes, a)
+	}
+
+	for _, block := range b.BlockTypes {
+		nb, err := toV5NestedBlock(path.WithAttributeName(block.TypeName), block)
+		if err != nil {
+			return nil, err
+		}
+		result.BlockTypes = append(result.BlockTypes, nb)
+	}
+
+	return result, nil
+}
+
+func toV5Attribute(path *tftypes.AttributePath, a *schema.SchemaAttribute) (*tfprotov5.SchemaAttribute, error) {
+	schemaAttribute := &tfprotov5.SchemaAttribute{
+		Name:            a.Name,
+		Required:        a.Required,
+		Optional:        a.Optional,
+		Computed:        a.Computed,
+		Sensitive:       a.Sensitive,
+		Description:     a.Description,
+		DescriptionKind: tfprotov5.StringKind(a.DescriptionKind),
+		Deprecated:      deprecated(a.DeprecationMessage),
+	}
+
+	if a.NestedType != nil {
+		return nil, path.NewErrorf("nested attribute types are not supported in protocol v5")
+	}
+	if a.Type == nil {
+		return nil, path.NewErrorf("must have Type set")
+	}
+	typ, err := tfType(path, *a.Type)
+	if err != nil {
+		return nil, err
+	}
+	schemaAttribute.Type = typ
+
+	return schemaAttribute, nil
+}
+
+func toV5NestedBlock(path *tftypes.AttributePath, b *schema.SchemaNestedBlock) (*tfprotov5.SchemaNestedBlock, error) {
+	schemaNestedBlock := &tfprotov5.SchemaNestedBlock{
+		TypeName: b.TypeName,
+		MinItems: int64(b.MinItems),
+		MaxItems: int64(b.MaxItems),
+	}
+
+	switch nm := b.Nesting; nm {
+	case schema.SchemaNestedBlockNestingModeSingle:
+		schemaNestedBlock.Nesting = tfprotov5.SchemaNestedBlockNestingModeSingle
+	case schema.SchemaNestedBlockNestingModeList:
+		schemaNestedBlock.Nesting = tfprotov5.SchemaNestedBlockNestingModeList
+	case schema.SchemaNestedBlockNestingModeSet:
+		schemaNestedBlock.Nesting = tfprotov5.SchemaNestedBlockNestingModeSet
+	case schema.SchemaNestedBlockNestingModeMap:
+		schemaNestedBlock.Nesting = tfprotov5.SchemaNestedBlockNestingModeMap
+	case schema.SchemaNestedBlockNestingModeGroup:
+		schemaNestedBlock.Nesting = tfprotov5.SchemaNestedBlockNestingModeGroup
+	default:
+		return nil, path.NewErrorf("unrecognized nesting mode %v", nm)
+	}
+
+	block, err := toV5Block(path, b.Block)
+	if err != nil {
+		return nil, err
+	}
+	block.Description = b.Description
+	block.DescriptionKind = tfprotov5.StringKind(b.DescriptionKind)
+	block.Deprecated = deprecated(b.DeprecationMessage)
+	schemaNestedBlock.Block = block
+
+	return schemaNestedBlock, nil
+}
@@ -0,0 +1,301 @@
+package proto_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/magodo/tfpluginschema/internal/proto"
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestFromV5ProviderSchema(t *testing.T) {
+	resp := &tfprotov5.GetProviderSchemaResponse{
+		Provider: &tfprotov5.Schema{
+			Block: &tfprotov5.SchemaBlock{
+				Attributes: []*tfprotov5.SchemaAttribute{
+					{
+						Name:      "token",
+						Type:      tftypes.String,
+						Optional:  true,
+						Sensitive: true,
+					},
+				},
+			},
+		},
+		ResourceSchemas: map[string]*tfprotov5.Schema{
+			"foo_resource": {
+				Version: 1,
+				Block: &tfprotov5.SchemaBlock{
+					Description: "A resource",
+					Attributes: []*tfprotov5.SchemaAttribute{
+						{
+							Name:     "string",
+							Type:     tftypes.String,
+							Required: true,
+						},
+						{
+							Name:            "set",
+							Type:            tftypes.Set{ElementType: tftypes.Bool},
+							Optional:        true,
+							Description:     "A `set`",
+							DescriptionKind: tfprotov5.StringKindMarkdown,
+						},
+					},
+					BlockTypes: []*tfprotov5.SchemaNestedBlock{
+						{
+							TypeName: "map",
+							Nesting:  tfprotov5.SchemaNestedBlockNestingModeMap,
+							Block: &tfprotov5.SchemaBlock{
+								Deprecated: true,
+								Attributes: []*tfprotov5.SchemaAttribute{
+									{
+										Name:     "number",
+										Type:     tftypes.Number,
+										Computed: true,
+									},
+								},
+							},
+						},
+						{
+							TypeName: "group",
+							Nesting:  tfprotov5.SchemaNestedBlockNestingModeGroup,
+						},
+					},
+				},
+			},
+		},
+		DataSourceSchemas: map[string]*tfprotov5.Schema{
+			"foo_datasource": {
+				Block: &tfprotov5.SchemaBlock{
+					Attributes: []*tfprotov5.SchemaAttribute{
+						{
+							Name:     "tuple",
+							Type:     tftypes.Tuple{ElementTypes: []tftypes.Type{tftypes.String, tftypes.Number}},
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got, err := proto.FromV5ProviderSchema(resp)
+	require.NoError(t, err)
+
+	want := &schema.ProviderSchema{
+		Provider: &schema.Schema{
+			Block: &schema.SchemaBlock{
+				Attributes: []*schema.SchemaAttribute{
+					{
+						Name:      "token",
+						Type:      &cty.String,
+						Optional:  true,
+						Sensitive: true,
+					},
+				},
+			},
+		},
+		ResourceSchemas: map[string]*schema.Schema{
+			"foo_resource": {
+				Version:     1,
+				Description: "A resource",
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{
+							Name:            "set",
+							Type:            ToPtr(cty.Set(cty.Bool)),
+							Optional:        true,
+							Description:     "A `set`",
+							DescriptionKind: schema.StringKindMarkdown,
+						},
+						{
+							Name:     "string",
+							Type:     &cty.String,
+							Required: true,
+						},
+					},
+					BlockTypes: []*schema.SchemaNestedBlock{
+						{
+							TypeName: "group",
+							Nesting:  schema.SchemaNestedBlockNestingModeGroup,
+							Block:    &schema.SchemaBlock{},
+						},
+						{
+							TypeName:           "map",
+							Nesting:            schema.SchemaNestedBlockNestingModeMap,
+							DeprecationMessage: schema.DefaultDeprecationMessage,
+							Block: &schema.SchemaBlock{
+								Attributes: []*schema.SchemaAttribute{
+									{
+										Name:     "number",
+										Type:     &cty.Number,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		DataSourceSchemas: map[string]*schema.Schema{
+			"foo_datasource": {
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{
+							Name:     "tuple",
+							Type:     ToPtr(cty.Tuple([]cty.Type{cty.String, cty.Number})),
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if !cmp.Equal(got, want, equateEmpty, typeComparer) {
+		t.Error(cmp.Diff(got, want, equateEmpty, typeComparer))
+	}
+}
+
+func TestFromV5ProviderSchema_Error(t *testing.T) {
+	_, err := proto.FromV5ProviderSchema(&tfprotov5.GetProviderSchemaResponse{
+		ResourceSchemas: map[string]*tfprotov5.Schema{
+			"foo": {
+				Block: &tfprotov5.SchemaBlock{
+					BlockTypes: []*tfprotov5.SchemaNestedBlock{
+						{
+							TypeName: "blk",
+							Block: &tfprotov5.SchemaBlock{
+								Attributes: []*tfprotov5.SchemaAttribute{
+									{
+										Name: "attr",
+									},
+								},
+							},
+							Nesting: tfprotov5.SchemaNestedBlockNestingModeSet,
+						},
+					},
+				},
+			},
+		},
+	})
+	require.EqualError(t, err, `converting resource schema (foo): AttributeName("blk").AttributeName("attr"): must have Type set`)
+}
+
+func TestFromV5ProviderSchema_Nil(t *testing.T) {
+	_, err := proto.FromV5ProviderSchema(nil)
+	require.EqualError(t, err, "getting provider schema: response is nil")
+
+	_, err = proto.FromV5ProviderSchema(&tfprotov5.GetProviderSchemaResponse{
+		DataSourceSchemas: map[string]*tfprotov5.Schema{
+			"foo": nil,
+		},
+	})
+	require.EqualError(t, err, "converting datasource schema (foo): schema is nil")
+}
+
+func TestFromV5Functions_Error(t *testing.T) {
+	_, err := proto.FromV5Functions(nil)
+	require.EqualError(t, err, "getting functions: response is nil")
+
+	_, err = proto.FromV5Functions(&tfprotov5.GetFunctionsResponse{
+		Functions: map[string]*tfprotov5.Function{
+			"foo": {
+				Parameters: []*tfprotov5.FunctionParameter{{Name: "a"}},
+				Return:     &tfprotov5.FunctionReturn{Type: tftypes.String},
+			},
+		},
+	})
+	require.EqualError(t, err, "converting function (foo): parameter 0: must have Type set")
+
+	_, err = proto.FromV5Functions(&tfprotov5.GetFunctionsResponse{
+		Functions: map[string]*tfprotov5.Function{
+			"foo": {},
+		},
+	})
+	require.EqualError(t, err, "converting function (foo): return: must have Type set")
+}
+
+func TestToV5ProviderSchema(t *testing.T) {
+	ps := &schema.ProviderSchema{
+		ResourceSchemas: map[string]*schema.Schema{
+			"foo_resource": {
+				Version:     1,
+				Description: "A resource",
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{
+							Name:     "string",
+							Type:     &cty.String,
+							Required: true,
+						},
+					},
+					BlockTypes: []*schema.SchemaNestedBlock{
+						{
+							TypeName: "set",
+							Nesting:  schema.SchemaNestedBlockNestingModeSet,
+							MaxItems: 3,
+							Block: &schema.SchemaBlock{
+								Attributes: []*schema.SchemaAttribute{
+									{
+										Name:      "object",
+										Type:      ToPtr(cty.Object(map[string]cty.Type{"a": cty.Bool})),
+										Optional:  true,
+										Sensitive: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	resp, err := proto.ToV5ProviderSchema(ps)
+	require.NoError(t, err)
+	require.Equal(t, tfprotov5.SchemaNestedBlockNestingModeSet, resp.ResourceSchemas["foo_resource"].Block.BlockTypes[0].Nesting)
+
+	got, err := proto.FromV5ProviderSchema(resp)
+	require.NoError(t, err)
+	if !cmp.Equal(got, ps, equateEmpty, typeComparer) {
+		t.Error(cmp.Diff(got, ps, equateEmpty, typeComparer))
+	}
+}
+
+func TestToV5Schema_Error(t *testing.T) {
+	_, err := proto.ToV5Schema(nil)
+	require.EqualError(t, err, "schema is nil")
+
+	_, err = proto.ToV5ProviderSchema(&schema.ProviderSchema{
+		ResourceSchemas: map[string]*schema.Schema{
+			"foo": nil,
+		},
+	})
+	require.EqualError(t, err, "converting resource schema (foo): schema is nil")
+
+	_, err = proto.ToV5ProviderSchema(nil)
+	require.EqualError(t, err, "provider schema is nil")
+}
+
+func TestToV5Schema_NestedType(t *testing.T) {
+	_, err := proto.ToV5Schema(&schema.Schema{
+		Block: &schema.SchemaBlock{
+			Attributes: []*schema.SchemaAttribute{
+				{
+					Name:     "nested",
+					Optional: true,
+					NestedType: &schema.SchemaObject{
+						Nesting: schema.SchemaObjectNestingModeSingle,
+					},
+				},
+			},
+		},
+	})
+	require.EqualError(t, err, `AttributeName("nested"): nested attribute types are not supported in protocol v5`)
+}
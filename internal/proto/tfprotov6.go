@@ -0,0 +1,531 @@
+package proto
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/magodo/tfpluginschema/schema"
+)
+
+func FromV6ProviderSchema(resp *tfprotov6.GetProviderSchemaResponse) (*schema.ProviderSchema, error) {
+	if resp == nil {
+		return nil, fmt.Errorf("getting provider schema: response is nil")
+	}
+	if err := v6DiagnosticsError(resp.Diagnostics); err != nil {
+		return nil, fmt.Errorf("getting provider schema: %v", err)
+	}
+
+	ret := &schema.ProviderSchema{
+		ResourceSchemas:          map[string]*schema.Schema{},
+		DataSourceSchemas:        map[string]*schema.Schema{},
+		EphemeralResourceSchemas: map[string]*schema.Schema{},
+		Functions:                map[string]*schema.Function{},
+	}
+
+	if resp.Provider != nil {
+		sch, err := FromV6Schema(resp.Provider)
+		if err != nil {
+			return nil, fmt.Errorf("converting provider schema: %v", err)
+		}
+		ret.Provider = sch
+	}
+	for name, res := range resp.ResourceSchemas {
+		sch, err := FromV6Schema(res)
+		if err != nil {
+			return nil, fmt.Errorf("converting resource schema (%s): %v", name, err)
+		}
+		ret.ResourceSchemas[name] = sch
+	}
+	for name, ds := range resp.DataSourceSchemas {
+		sch, err := FromV6Schema(ds)
+		if err != nil {
+			return nil, fmt.Errorf("converting datasource schema (%s): %v", name, err)
+		}
+		ret.DataSourceSchemas[name] = sch
+	}
+	for name, er := range resp.EphemeralResourceSchemas {
+		sch, err := FromV6Schema(er)
+		if err != nil {
+			return nil, fmt.Errorf("converting ephemeral resource schema (%s): %v", name, err)
+		}
+		ret.EphemeralResourceSchemas[name] = sch
+	}
+	for name, f := range resp.Functions {
+		fn, err := fromV6Function(f)
+		if err != nil {
+			return nil, fmt.Errorf("converting function (%s): %v", name, err)
+		}
+		ret.Functions[name] = fn
+	}
+	return ret, nil
+}
+
+// FromV6Functions converts the functions of the GetFunctions response.
+func FromV6Functions(resp *tfprotov6.GetFunctionsResponse) (map[string]*schema.Function, error) {
+	if resp == nil {
+		return nil, fmt.Errorf("getting functions: response is nil")
+	}
+	if err := v6DiagnosticsError(resp.Diagnostics); err != nil {
+		return nil, fmt.Errorf("getting functions: %v", err)
+	}
+	ret := map[string]*schema.Function{}
+	for name, f := range resp.Functions {
+		fn, err := fromV6Function(f)
+		if err != nil {
+			return nil, fmt.Errorf("converting function (%s): %v", name, err)
+		}
+		ret[name] = fn
+	}
+	return ret, nil
+}
+
+func fromV6Function(f *tfprotov6.Function) (*schema.Function, error) {
+	if f == nil {
+		return nil, fmt.Errorf("function is nil")
+	}
+	ret := &schema.Function{
+		Summary:            f.Summary,
+		Description:        f.Description,
+		DescriptionKind:    schema.StringKind(f.DescriptionKind),
+		DeprecationMessage: f.DeprecationMessage,
+	}
+	for i, p := range f.Parameters {
+		param, err := fromV6FunctionParameter(p)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %d: %v", i, err)
+		}
+		ret.Parameters = append(ret.Parameters, param)
+	}
+	if f.VariadicParameter != nil {
+		param, err := fromV6FunctionParameter(f.VariadicParameter)
+		if err != nil {
+			return nil, fmt.Errorf("variadic parameter: %v", err)
+		}
+		ret.VariadicParameter = param
+	}
+	if f.Return == nil || f.Return.Type == nil {
+		return nil, fmt.Errorf("return: must have Type set")
+	}
+	typ, err := ctyType(tftypes.NewAttributePath(), f.Return.Type)
+	if err != nil {
+		return nil, fmt.Errorf("return: %v", err)
+	}
+	ret.ReturnType = typ
+	return ret, nil
+}
+
+func fromV6FunctionParameter(p *tfprotov6.FunctionParameter) (*schema.FunctionParameter, error) {
+	if p == nil {
+		return nil, fmt.Errorf("parameter is nil")
+	}
+	if p.Type == nil {
+		return nil, fmt.Errorf("must have Type set")
+	}
+	typ, err := ctyType(tftypes.NewAttributePath(), p.Type)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.FunctionParameter{
+		Name:               p.Name,
+		Type:               typ,
+		AllowNullValue:     p.AllowNullValue,
+		AllowUnknownValues: p.AllowUnknownValues,
+		Description:        p.Description,
+		DescriptionKind:    schema.StringKind(p.DescriptionKind),
+	}, nil
+}
+
+func FromV6Schema(s *tfprotov6.Schema) (*schema.Schema, error) {
+	if s == nil {
+		return nil, fmt.Errorf("schema is nil")
+	}
+	result := &schema.Schema{
+		Version: s.Version,
+	}
+	if s.Block == nil {
+		return result, nil
+	}
+	result.Description = s.Block.Description
+	result.DescriptionKind = schema.StringKind(s.Block.DescriptionKind)
+	result.DeprecationMessage = deprecationMessage(s.Block.Deprecated)
+
+	block, err := fromV6Block(tftypes.NewAttributePath(), s.Block)
+	if err != nil {
+		return nil, err
+	}
+	result.Block = block
+	return result, nil
+}
+
+func fromV6Block(path *tftypes.AttributePath, b *tfprotov6.SchemaBlock) (*schema.SchemaBlock, error) {
+	result := &schema.SchemaBlock{}
+
+	for _, attr := range b.Attributes {
+		a, err := fromV6Attribute(path.WithAttributeName(attr.Name), attr)
+		if err != nil {
+			return nil, err
+		}
+		result.Attributes = append(result.Attributes, a)
+	}
+
+	for _, block := range b.BlockTypes {
+		nb, err := fromV6NestedBlock(path.WithAttributeName(block.TypeName), block)
+		if err != nil {
+			return nil, err
+		}
+		result.BlockTypes = append(result.BlockTypes, nb)
+	}
+
+	sort.Slice(result.Attributes, func(i, j int) bool {
+		return result.Attributes[i].Name < result.Attributes[j].Name
+	})
+
+	sort.Slice(result.BlockTypes, func(i, j int) bool {
+		return result.BlockTypes[i].TypeName < result.BlockTypes[j].TypeName
+	})
+
+	return result, nil
+}
+
+func fromV6Attribute(path *tftypes.AttributePath, a *tfprotov6.SchemaAttribute) (*schema.SchemaAttribute, error) {
+	typ, err := ctyType(path, a.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaAttribute := &schema.SchemaAttribute{
+		Name:               a.Name,
+		Type:               typ,
+		Required:           a.Required,
+		Optional:           a.Optional,
+		Computed:           a.Computed,
+		Sensitive:          a.Sensitive,
+		Description:        a.Description,
+		DescriptionKind:    schema.StringKind(a.DescriptionKind),
+		DeprecationMessage: deprecationMessage(a.Deprecated),
+	}
+
+	if a.NestedType == nil {
+		if typ == nil {
+			return nil, path.NewErrorf("must have Type or NestedType set")
+		}
+		return schemaAttribute, nil
+	}
+
+	object := &schema.SchemaObject{}
+	switch nm := a.NestedType.Nesting; nm {
+	case tfprotov6.SchemaObjectNestingModeSingle:
+		object.Nesting = schema.SchemaObjectNestingModeSingle
+	case tfprotov6.SchemaObjectNestingModeList:
+		object.Nesting = schema.SchemaObjectNestingModeList
+	case tfprotov6.SchemaObjectNestingModeSet:
+		object.Nesting = schema.SchemaObjectNestingModeSet
+	case tfprotov6.SchemaObjectNestingModeMap:
+		object.Nesting = schema.SchemaObjectNestingModeMap
+	default:
+		return nil, path.NewErrorf("unrecognized nesting mode %v", nm)
+	}
+
+	for _, nestedA := range a.NestedType.Attributes {
+		nestedSchemaAttribute, err := fromV6Attribute(path.WithAttributeName(nestedA.Name), nestedA)
+		if err != nil {
+			return nil, err
+		}
+		object.Attributes = append(object.Attributes, nestedSchemaAttribute)
+	}
+
+	sort.Slice(object.Attributes, func(i, j int) bool {
+		return object.Attributes[i].Name < object.Attributes[j].Name
+	})
+
+	schemaAttribute.NestedType = object
+	schemaAttribute.Type = nil
+
+	return schemaAttribute, nil
+}
+
+func fromV6NestedBlock(path *tftypes.AttributePath, b *tfprotov6.SchemaNestedBlock) (*schema.SchemaNestedBlock, error) {
+	schemaNestedBlock := &schema.SchemaNestedBlock{
+		TypeName: b.TypeName,
+		MinItems: int(b.MinItems),
+		MaxItems: int(b.MaxItems),
+	}
+
+	switch nm := b.Nesting; nm {
+	case tfprotov6.SchemaNestedBlockNestingModeSingle:
+		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeSingle
+	case tfprotov6.SchemaNestedBlockNestingModeList:
+		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeList
+	case tfprotov6.SchemaNestedBlockNestingModeSet:
+		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeSet
+	case tfprotov6.SchemaNestedBlockNestingModeMap:
+		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeMap
+	case tfprotov6.SchemaNestedBlockNestingModeGroup:
+		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeGroup
+	default:
+		return nil, path.NewErrorf("unrecognized nesting mode %v", nm)
+	}
+
+	if b.Block == nil {
+		schemaNestedBlock.Block = &schema.SchemaBlock{}
+		return schemaNestedBlock, nil
+	}
+
+	schemaNestedBlock.Description = b.Block.Description
+	schemaNestedBlock.DescriptionKind = schema.StringKind(b.Block.DescriptionKind)
+	schemaNestedBlock.DeprecationMessage = deprecationMessage(b.Block.Deprecated)
+
+	block, err := fromV6Block(path, b.Block)
+	if err != nil {
+		return nil, err
+	}
+	schemaNestedBlock.Block = block
+
+	return schemaNestedBlock, nil
+}
+
+func v6DiagnosticsError(diags []*tfprotov6.Diagnostic) error {
+	var errs []error
+	for _, diag := range diags {
+		if diag == nil || diag.Severity != tfprotov6.DiagnosticSeverityError {
+			continue
+		}
+		errs = append(errs, fmt.Errorf("%s: %s", diag.Summary, diag.Detail))
+	}
+	return errors.Join(errs...)
+}
+
+func ToV6ProviderSchema(ps *schema.ProviderSchema) (*tfprotov6.GetProviderSchemaResponse, error) {
+	if ps == nil {
+		return nil, fmt.Errorf("provider schema is nil")
+	}
+	ret := &tfprotov6.GetProviderSchemaResponse{
+		ResourceSchemas:          map[string]*tfprotov6.Schema{},
+		DataSourceSchemas:        map[string]*tfprotov6.Schema{},
+		EphemeralResourceSchemas: map[string]*tfprotov6.Schema{},
+		Functions:                map[string]*tfprotov6.Function{},
+	}
+
+	if ps.Provider != nil {
+		sch, err := ToV6Schema(ps.Provider)
+		if err != nil {
+			return nil, fmt.Errorf("converting provider schema: %v", err)
+		}
+		ret.Provider = sch
+	}
+	for name, res := range ps.ResourceSchemas {
+		sch, err := ToV6Schema(res)
+		if err != nil {
+			return nil, fmt.Errorf("converting resource schema (%s): %v", name, err)
+		}
+		ret.ResourceSchemas[name] = sch
+	}
+	for name, ds := range ps.DataSourceSchemas {
+		sch, err := ToV6Schema(ds)
+		if err != nil {
+			return nil, fmt.Errorf("converting datasource schema (%s): %v", name, err)
+		}
+		ret.DataSourceSchemas[name] = sch
+	}
+	for name, er := range ps.EphemeralResourceSchemas {
+		sch, err := ToV6Schema(er)
+		if err != nil {
+			return nil, fmt.Errorf("converting ephemeral resource schema (%s): %v", name, err)
+		}
+		ret.EphemeralResourceSchemas[name] = sch
+	}
+	for name, f := range ps.Functions {
+		fn, err := toV6Function(f)
+		if err != nil {
+			return nil, fmt.Errorf("converting function (%s): %v", name, err)
+		}
+		ret.Functions[name] = fn
+	}
+	return ret, nil
+}
+
+func toV6Function(f *schema.Function) (*tfprotov6.Function, error) {
+	ret := &tfprotov6.Function{
+		Parameters:         []*tfprotov6.FunctionParameter{},
+		Summary:            f.Summary,
+		Description:        f.Description,
+		DescriptionKind:    tfprotov6.StringKind(f.DescriptionKind),
+		DeprecationMessage: f.DeprecationMessage,
+	}
+	for i, p := range f.Parameters {
+		param, err := toV6FunctionParameter(p)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %d: %v", i, err)
+		}
+		ret.Parameters = append(ret.Parameters, param)
+	}
+	if f.VariadicParameter != nil {
+		param, err := toV6FunctionParameter(f.VariadicParameter)
+		if err != nil {
+			return nil, fmt.Errorf("variadic parameter: %v", err)
+		}
+		ret.VariadicParameter = param
+	}
+	if f.ReturnType == nil {
+		return nil, fmt.Errorf("return type is nil")
+	}
+	typ, err := tfType(tftypes.NewAttributePath(), *f.ReturnType)
+	if err != nil {
+		return nil, fmt.Errorf("return: %v", err)
+	}
+	ret.Return = &tfprotov6.FunctionReturn{Type: typ}
+	return ret, nil
+}
+
+func toV6FunctionParameter(p *schema.FunctionParameter) (*tfprotov6.FunctionParameter, error) {
+	if p.Type == nil {
+		return nil, fmt.Errorf("type is nil")
+	}
+	typ, err := tfType(tftypes.NewAttributePath(), *p.Type)
+	if err != nil {
+		return nil, err
+	}
+	return &tfprotov6.FunctionParameter{
+		Name:               p.Name,
+		Type:               typ,
+		AllowNullValue:     p.AllowNullValue,
+		AllowUnknownValues: p.AllowUnknownValues,
+		Description:        p.Description,
+		DescriptionKind:    tfprotov6.StringKind(p.DescriptionKind),
+	}, nil
+}
+
+func ToV6Schema(s *schema.Schema) (*tfprotov6.Schema, error) {
+	if s == nil {
+		return nil, fmt.Errorf("schema is nil")
+	}
+	block, err := ToV6Block(s.Block)
+	if err != nil {
+		return nil, err
+	}
+	block.Description = s.Description
+	block.DescriptionKind = tfprotov6.StringKind(s.DescriptionKind)
+	block.Deprecated = deprecated(s.DeprecationMessage)
+
+	return &tfprotov6.Schema{
+		Version: s.Version,
+		Block:   block,
+	}, nil
+}
+
+func ToV6Block(b *schema.SchemaBlock) (*tfprotov6.SchemaBlock, error) {
+	return toV6Block(tftypes.NewAttributePath(), b)
+}
+
+func toV6Block(path *tftypes.AttributePath, b *schema.SchemaBlock) (*tfprotov6.SchemaBlock, error) {
+	result := &tfprotov6.SchemaBlock{}
+	if b == nil {
+		return result, nil
+	}
+
+	for _, attr := range b.Attributes {
+		a, err := toV6Attribute(path.WithAttributeName(attr.Name), attr)
+		if err != nil {
+			return nil, err
+		}
+		result.Attributes = append(result.Attributes, a)
+	}
+
+	for _, block := range b.BlockTypes {
+		nb, err := toV6NestedBlock(path.WithAttributeName(block.TypeName), block)
+		if err != nil {
+			return nil, err
+		}
+		result.BlockTypes = append(result.BlockTypes, nb)
+	}
+
+	return result, nil
+}
+
+func toV6Attribute(path *tftypes.AttributePath, a *schema.SchemaAttribute) (*tfprotov6.SchemaAttribute, error) {
+	schemaAttribute := &tfprotov6.SchemaAttribute{
+		Name:            a.Name,
+		Required:        a.Required,
+		Optional:        a.Optional,
+		Computed:        a.Computed,
+		Sensitive:       a.Sensitive,
+		Description:     a.Description,
+		DescriptionKind: tfprotov6.StringKind(a.DescriptionKind),
+		Deprecated:      deprecated(a.DeprecationMessage),
+	}
+
+	if a.NestedType == nil {
+		if a.Type == nil {
+			return nil, path.NewErrorf("must have Type or NestedType set")
+		}
+		typ, err := tfType(path, *a.Type)
+		if err != nil {
+			return nil, err
+		}
+		schemaAttribute.Type = typ
+		return schemaAttribute, nil
+	}
+
+	object := &tfprotov6.SchemaObject{}
+	switch nm := a.NestedType.Nesting; nm {
+	case schema.SchemaObjectNestingModeSingle:
+		object.Nesting = tfprotov6.SchemaObjectNestingModeSingle
+	case schema.SchemaObjectNestingModeList:
+		object.Nesting = tfprotov6.SchemaObjectNestingModeList
+	case schema.SchemaObjectNestingModeSet:
+		object.Nesting = tfprotov6.SchemaObjectNestingModeSet
+	case schema.SchemaObjectNestingModeMap:
+		object.Nesting = tfprotov6.SchemaObjectNestingModeMap
+	default:
+		return nil, path.NewErrorf("unrecognized nesting mode %v", nm)
+	}
+
+	for _, nestedA := range a.NestedType.Attributes {
+		nestedSchemaAttribute, err := toV6Attribute(path.WithAttributeName(nestedA.Name), nestedA)
+		if err != nil {
+			return nil, err
+		}
+		object.Attributes = append(object.Attributes, nestedSchemaAttribute)
+	}
+
+	schemaAttribute.NestedType = object
+
+	return schemaAttribute, nil
+}
+
+func toV6NestedBlock(path *tftypes.AttributePath, b *schema.SchemaNestedBlock) (*tfprotov6.SchemaNestedBlock, error) {
+	schemaNestedBlock := &tfprotov6.SchemaNestedBlock{
+		TypeName: b.TypeName,
+		MinItems: int64(b.MinItems),
+		MaxItems: int64(b.MaxItems),
+	}
+
+	switch nm := b.Nesting; nm {
+	case schema.SchemaNestedBlockNestingModeSingle:
+		schemaNestedBlock.Nesting = tfprotov6.SchemaNestedBlockNestingModeSingle
+	case schema.SchemaNestedBlockNestingModeList:
+		schemaNestedBlock.Nesting = tfprotov6.SchemaNestedBlockNestingModeList
+	case schema.SchemaNestedBlockNestingModeSet:
+		schemaNestedBlock.Nesting = tfprotov6.SchemaNestedBlockNestingModeSet
+	case schema.SchemaNestedBlockNestingModeMap:
+		schemaNestedBlock.Nesting = tfprotov6.SchemaNestedBlockNestingModeMap
+	case schema.SchemaNestedBlockNestingModeGroup:
+		schemaNestedBlock.Nesting = tfprotov6.SchemaNestedBlockNestingModeGroup
+	default:
+		return nil, path.NewErrorf("unrecognized nesting mode %v", nm)
+	}
+
+	block, err := toV6Block(path, b.Block)
+	if err != nil {
+		return nil, err
+	}
+	block.Description = b.Description
+	block.DescriptionKind = tfprotov6.StringKind(b.DescriptionKind)
+	block.Deprecated = deprecated(b.DeprecationMessage)
+	schemaNestedBlock.Block = block
+
+	return schemaNestedBlock, nil
+}
@@ -0,0 +1,562 @@
+package proto_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/magodo/tfpluginschema/internal/proto"
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var (
+	typeComparer = cmp.Comparer(cty.Type.Equals)
+	equateEmpty  = cmpopts.EquateEmpty()
+)
+
+func TestFromV6ProviderSchema(t *testing.T) {
+	resp := &tfprotov6.GetProviderSchemaResponse{
+		Provider: &tfprotov6.Schema{
+			Block: &tfprotov6.SchemaBlock{
+				Attributes: []*tfprotov6.SchemaAttribute{
+					{
+						Name:      "token",
+						Type:      tftypes.String,
+						Optional:  true,
+						Sensitive: true,
+					},
+				},
+			},
+		},
+		ResourceSchemas: map[string]*tfprotov6.Schema{
+			"foo_resource": {
+				Version: 1,
+				Block: &tfprotov6.SchemaBlock{
+					Description:     "A `resource`",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Deprecated:      true,
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "string",
+							Type:     tftypes.String,
+							Required: true,
+						},
+						{
+							Name:        "list",
+							Type:        tftypes.List{ElementType: tftypes.Number},
+							Optional:    true,
+							Computed:    true,
+							Description: "A list",
+							Deprecated:  true,
+						},
+						{
+							Name:     "nested",
+							Optional: true,
+							NestedType: &tfprotov6.SchemaObject{
+								Nesting: tfprotov6.SchemaObjectNestingModeSet,
+								Attributes: []*tfprotov6.SchemaAttribute{
+									{
+										Name:     "object",
+										Type:     tftypes.Object{AttributeTypes: map[string]tftypes.Type{"a": tftypes.Bool}},
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+					BlockTypes: []*tfprotov6.SchemaNestedBlock{
+						{
+							TypeName: "single",
+							Nesting:  tfprotov6.SchemaNestedBlockNestingModeSingle,
+							Block:    &tfprotov6.SchemaBlock{},
+						},
+						{
+							TypeName: "list",
+							Nesting:  tfprotov6.SchemaNestedBlockNestingModeList,
+							MinItems: 1,
+							MaxItems: 2,
+							Block: &tfprotov6.SchemaBlock{
+								Description: "A list block",
+								Attributes: []*tfprotov6.SchemaAttribute{
+									{
+										Name:     "map",
+										Type:     tftypes.Map{ElementType: tftypes.String},
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		DataSourceSchemas: map[string]*tfprotov6.Schema{
+			"foo_datasource": {
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "dynamic",
+							Type:     tftypes.DynamicPseudoType,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got, err := proto.FromV6ProviderSchema(resp)
+	require.NoError(t, err)
+
+	want := &schema.ProviderSchema{
+		Provider: &schema.Schema{
+			Block: &schema.SchemaBlock{
+				Attributes: []*schema.SchemaAttribute{
+					{
+						Name:      "token",
+						Type:      &cty.String,
+						Optional:  true,
+						Sensitive: true,
+					},
+				},
+			},
+		},
+		ResourceSchemas: map[string]*schema.Schema{
+			"foo_resource": {
+				Version:            1,
+				Description:        "A `resource`",
+				DescriptionKind:    schema.StringKindMarkdown,
+				DeprecationMessage: schema.DefaultDeprecationMessage,
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{
+							Name:               "list",
+							Type:               ToPtr(cty.List(cty.Number)),
+							Optional:           true,
+							Computed:           true,
+							Description:        "A list",
+							DeprecationMessage: schema.DefaultDeprecationMessage,
+						},
+						{
+							Name:     "nested",
+							Optional: true,
+							NestedType: &schema.SchemaObject{
+								Nesting: schema.SchemaObjectNestingModeSet,
+								Attributes: []*schema.SchemaAttribute{
+									{
+										Name:     "object",
+										Type:     ToPtr(cty.Object(map[string]cty.Type{"a": cty.Bool})),
+										Optional: true,
+									},
+								},
+							},
+						},
+						{
+							Name:     "string",
+							Type:     &cty.String,
+							Required: true,
+						},
+					},
+					BlockTypes: []*schema.SchemaNestedBlock{
+						{
+							TypeName:    "list",
+							Nesting:     schema.SchemaNestedBlockNestingModeList,
+							MinItems:    1,
+							MaxItems:    2,
+							Description: "A list block",
+							Block: &schema.SchemaBlock{
+								Attributes: []*schema.SchemaAttribute{
+									{
+										Name:     "map",
+										Type:     ToPtr(cty.Map(cty.String)),
+										Required: true,
+									},
+								},
+							},
+						},
+						{
+							TypeName: "single",
+							Nesting:  schema.SchemaNestedBlockNestingModeSingle,
+							Block:    &schema.SchemaBlock{},
+						},
+					},
+				},
+			},
+		},
+		DataSourceSchemas: map[string]*schema.Schema{
+			"foo_datasource": {
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{
+							Name:     "dynamic",
+							Type:     &cty.DynamicPseudoType,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if !cmp.Equal(got, want, equateEmpty, typeComparer) {
+		t.Error(cmp.Diff(got, want, equateEmpty, typeComparer))
+	}
+}
+
+func TestFromV6ProviderSchema_Error(t *testing.T) {
+	cases := map[string]struct {
+		resp *tfprotov6.GetProviderSchemaResponse
+		err  string
+	}{
+		"diagnostics": {
+			resp: &tfprotov6.GetProviderSchemaResponse{
+				Diagnostics: []*tfprotov6.Diagnostic{
+					{
+						Severity: tfprotov6.DiagnosticSeverityWarning,
+						Summary:  "warning",
+					},
+					{
+						Severity: tfprotov6.DiagnosticSeverityError,
+						Summary:  "error",
+						Detail:   "detail",
+					},
+				},
+			},
+			err: "getting provider schema: error: detail",
+		},
+		"invalid nesting mode": {
+			resp: &tfprotov6.GetProviderSchemaResponse{
+				ResourceSchemas: map[string]*tfprotov6.Schema{
+					"foo": {
+						Block: &tfprotov6.SchemaBlock{
+							BlockTypes: []*tfprotov6.SchemaNestedBlock{
+								{
+									TypeName: "blk",
+									Block: &tfprotov6.SchemaBlock{
+										Attributes: []*tfprotov6.SchemaAttribute{
+											{
+												Name: "nested",
+												NestedType: &tfprotov6.SchemaObject{
+													Attributes: []*tfprotov6.SchemaAttribute{},
+												},
+											},
+										},
+									},
+									Nesting: tfprotov6.SchemaNestedBlockNestingModeList,
+								},
+							},
+						},
+					},
+				},
+			},
+			err: `converting resource schema (foo): AttributeName("blk").AttributeName("nested"): unrecognized nesting mode INVALID`,
+		},
+		"missing type": {
+			resp: &tfprotov6.GetProviderSchemaResponse{
+				DataSourceSchemas: map[string]*tfprotov6.Schema{
+					"foo": {
+						Block: &tfprotov6.SchemaBlock{
+							Attributes: []*tfprotov6.SchemaAttribute{
+								{
+									Name: "attr",
+								},
+							},
+						},
+					},
+				},
+			},
+			err: `converting datasource schema (foo): AttributeName("attr"): must have Type or NestedType set`,
+		},
+		"nil response": {
+			err: "getting provider schema: response is nil",
+		},
+		"nil schema": {
+			resp: &tfprotov6.GetProviderSchemaResponse{
+				ResourceSchemas: map[string]*tfprotov6.Schema{
+					"foo": nil,
+				},
+			},
+			err: "converting resource schema (foo): schema is nil",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := proto.FromV6ProviderSchema(tt.resp)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func ToPtr[T any](v T) *T {
+	return &v
+}
+
+func TestToV6ProviderSchema(t *testing.T) {
+	ps := &schema.ProviderSchema{
+		Provider: &schema.Schema{
+			Block: &schema.SchemaBlock{
+				Attributes: []*schema.SchemaAttribute{
+					{
+						Name:      "token",
+						Type:      &cty.String,
+						Optional:  true,
+						Sensitive: true,
+					},
+				},
+			},
+		},
+		ResourceSchemas: map[string]*schema.Schema{
+			"foo_resource": {
+				Version:            1,
+				Description:        "A `resource`",
+				DescriptionKind:    schema.StringKindMarkdown,
+				DeprecationMessage: schema.DefaultDeprecationMessage,
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{
+							Name:     "nested",
+							Optional: true,
+							NestedType: &schema.SchemaObject{
+								Nesting: schema.SchemaObjectNestingModeMap,
+								Attributes: []*schema.SchemaAttribute{
+									{
+										Name:     "object",
+										Type:     ToPtr(cty.ObjectWithOptionalAttrs(map[string]cty.Type{"a": cty.Bool, "b": cty.Set(cty.String)}, []string{"b"})),
+										Optional: true,
+									},
+								},
+							},
+						},
+						{
+							Name:     "tuple",
+							Type:     ToPtr(cty.Tuple([]cty.Type{cty.String, cty.DynamicPseudoType})),
+							Computed: true,
+						},
+					},
+					BlockTypes: []*schema.SchemaNestedBlock{
+						{
+							TypeName:    "group",
+							Nesting:     schema.SchemaNestedBlockNestingModeGroup,
+							Description: "A group block",
+							Block:       &schema.SchemaBlock{},
+						},
+						{
+							TypeName:           "list",
+							Nesting:            schema.SchemaNestedBlockNestingModeList,
+							MinItems:           1,
+							MaxItems:           2,
+							DeprecationMessage: schema.DefaultDeprecationMessage,
+							Block: &schema.SchemaBlock{
+								Attributes: []*schema.SchemaAttribute{
+									{
+										Name:     "map",
+										Type:     ToPtr(cty.Map(cty.List(cty.Number))),
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		DataSourceSchemas: map[string]*schema.Schema{
+			"foo_datasource": {
+				Block: &schema.SchemaBlock{},
+			},
+		},
+	}
+
+	resp, err := proto.ToV6ProviderSchema(ps)
+	require.NoError(t, err)
+
+	res := resp.ResourceSchemas["foo_resource"]
+	require.True(t, res.Block.Deprecated)
+	require.Equal(t, tfprotov6.StringKindMarkdown, res.Block.DescriptionKind)
+	require.Equal(t, tfprotov6.SchemaObjectNestingModeMap, res.Block.Attributes[0].NestedType.Nesting)
+	require.True(t, res.Block.Attributes[1].Type.Equal(tftypes.Tuple{ElementTypes: []tftypes.Type{tftypes.String, tftypes.DynamicPseudoType}}))
+	require.Equal(t, tfprotov6.SchemaNestedBlockNestingModeGroup, res.Block.BlockTypes[0].Nesting)
+	require.Equal(t, "A group block", res.Block.BlockTypes[0].Block.Description)
+	require.True(t, res.Block.BlockTypes[1].Block.Deprecated)
+
+	got, err := proto.FromV6ProviderSchema(resp)
+	require.NoError(t, err)
+	if !cmp.Equal(got, ps, equateEmpty, typeComparer) {
+		t.Error(cmp.Diff(got, ps, equateEmpty, typeComparer))
+	}
+}
+
+func TestToV6Schema_Error(t *testing.T) {
+	_, err := proto.ToV6Schema(&schema.Schema{
+		Block: &schema.SchemaBlock{
+			BlockTypes: []*schema.SchemaNestedBlock{
+				{
+					TypeName: "blk",
+					Nesting:  schema.SchemaNestedBlockNestingModeList,
+					Block: &schema.SchemaBlock{
+						Attributes: []*schema.SchemaAttribute{
+							{
+								Name: "attr",
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	require.EqualError(t, err, `AttributeName("blk").AttributeName("attr"): must have Type or NestedType set`)
+
+	_, err = proto.ToV6Schema(nil)
+	require.EqualError(t, err, "schema is nil")
+
+	_, err = proto.ToV6ProviderSchema(&schema.ProviderSchema{
+		DataSourceSchemas: map[string]*schema.Schema{
+			"foo": nil,
+		},
+	})
+	require.EqualError(t, err, "converting datasource schema (foo): schema is nil")
+}
+
+func TestV6Functions(t *testing.T) {
+	resp := &tfprotov6.GetFunctionsResponse{
+		Functions: map[string]*tfprotov6.Function{
+			"parse_id": {
+				Parameters: []*tfprotov6.FunctionParameter{
+					{
+						Name:            "id",
+						Type:            tftypes.String,
+						AllowNullValue:  true,
+						Description:     "The **resource** id",
+						DescriptionKind: tfprotov6.StringKindMarkdown,
+					},
+				},
+				VariadicParameter: &tfprotov6.FunctionParameter{
+					Name:               "segments",
+					Type:               tftypes.Number,
+					AllowUnknownValues: true,
+				},
+				Return: &tfprotov6.FunctionReturn{
+					Type: tftypes.Object{AttributeTypes: map[string]tftypes.Type{"name": tftypes.String}},
+				},
+				Summary:            "Parse an id",
+				Description:        "Parse a resource id",
+				DeprecationMessage: "deprecated",
+			},
+		},
+	}
+
+	got, err := proto.FromV6Functions(resp)
+	require.NoError(t, err)
+
+	want := map[string]*schema.Function{
+		"parse_id": {
+			Parameters: []*schema.FunctionParameter{
+				{
+					Name:            "id",
+					Type:            &cty.String,
+					AllowNullValue:  true,
+					Description:     "The **resource** id",
+					DescriptionKind: schema.StringKindMarkdown,
+				},
+			},
+			VariadicParameter: &schema.FunctionParameter{
+				Name:               "segments",
+				Type:               &cty.Number,
+				AllowUnknownValues: true,
+			},
+			ReturnType:         ToPtr(cty.Object(map[string]cty.Type{"name": cty.String})),
+			Summary:            "Parse an id",
+			Description:        "Parse a resource id",
+			DeprecationMessage: "deprecated",
+		},
+	}
+	if !cmp.Equal(got, want, equateEmpty, typeComparer) {
+		t.Error(cmp.Diff(got, want, equateEmpty, typeComparer))
+	}
+
+	pr, err := proto.ToV6ProviderSchema(&schema.ProviderSchema{Functions: got})
+	require.NoError(t, err)
+	if !cmp.Equal(pr.Functions, resp.Functions, equateEmpty) {
+		t.Error(cmp.Diff(pr.Functions, resp.Functions, equateEmpty))
+	}
+
+	_, err = proto.FromV6Functions(&tfprotov6.GetFunctionsResponse{
+		Diagnostics: []*tfprotov6.Diagnostic{
+			{
+				Severity: tfprotov6.DiagnosticSeverityError,
+				Summary:  "error",
+				Detail:   "detail",
+			},
+		},
+	})
+	require.EqualError(t, err, "getting functions: error: detail")
+}
+
+func TestFromV6Functions_Error(t *testing.T) {
+	cases := map[string]struct {
+		resp *tfprotov6.GetFunctionsResponse
+		err  string
+	}{
+		"nil response": {
+			err: "getting functions: response is nil",
+		},
+		"missing return": {
+			resp: &tfprotov6.GetFunctionsResponse{
+				Functions: map[string]*tfprotov6.Function{
+					"foo": {},
+				},
+			},
+			err: "converting function (foo): return: must have Type set",
+		},
+		"missing return type": {
+			resp: &tfprotov6.GetFunctionsResponse{
+				Functions: map[string]*tfprotov6.Function{
+					"foo": {Return: &tfprotov6.FunctionReturn{}},
+				},
+			},
+			err: "converting function (foo): return: must have Type set",
+		},
+		"missing parameter type": {
+			resp: &tfprotov6.GetFunctionsResponse{
+				Functions: map[string]*tfprotov6.Function{
+					"foo": {
+						Parameters: []*tfprotov6.FunctionParameter{
+							{Name: "a", Type: tftypes.String},
+							{Name: "b"},
+						},
+						Return: &tfprotov6.FunctionReturn{Type: tftypes.String},
+					},
+				},
+			},
+			err: "converting function (foo): parameter 1: must have Type set",
+		},
+		"missing variadic parameter type": {
+			resp: &tfprotov6.GetFunctionsResponse{
+				Functions: map[string]*tfprotov6.Function{
+					"foo": {
+						VariadicParameter: &tfprotov6.FunctionParameter{Name: "a"},
+						Return:            &tfprotov6.FunctionReturn{Type: tftypes.String},
+					},
+				},
+			},
+			err: "converting function (foo): variadic parameter: must have Type set",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := proto.FromV6Functions(tt.resp)
+			require.EqualError(t, err, tt.err)
+
+			if tt.resp != nil {
+				_, err = proto.FromV6ProviderSchema(&tfprotov6.GetProviderSchemaResponse{Functions: tt.resp.Functions})
+				require.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
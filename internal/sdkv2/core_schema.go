@@ -3,17 +3,34 @@ package sdkv2
 // A modified version based on: github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema/core_schema.go
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/magodo/tfpluginschema/schema"
 	"github.com/zclconf/go-cty/cty"
 )
 
+// FromSchemaMap is like FromSchemaMapE, but panics if the schema map is invalid.
 func FromSchemaMap(m map[string]*sdkschema.Schema) *schema.SchemaBlock {
+	ret, err := FromSchemaMapE(m)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+// FromSchemaMapE converts the schema map. All the problems found in the schema map are collected and returned as
+// a joined error, each of which is a tftypes.AttributePathError pointing to the offending field.
+func FromSchemaMapE(m map[string]*sdkschema.Schema) (*schema.SchemaBlock, error) {
+	return fromSchemaMap(tftypes.NewAttributePath(), m)
+}
+
+func fromSchemaMap(path *tftypes.AttributePath, m map[string]*sdkschema.Schema) (*schema.SchemaBlock, error) {
 	if len(m) == 0 {
-		return &schema.SchemaBlock{}
+		return &schema.SchemaBlock{}, nil
 	}
 
 	ret := &schema.SchemaBlock{
@@ -21,9 +38,38 @@ func FromSchemaMap(m map[string]*sdkschema.Schema) *schema.SchemaBlock {
 		BlockTypes: []*schema.SchemaNestedBlock{},
 	}
 
-	for name, ps := range m {
+	// Iterate in a stable order so that the collected errors are deterministic.
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	addAttribute := func(attr *schema.SchemaAttribute, err error) {
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		ret.Attributes = append(ret.Attributes, attr)
+	}
+	addBlock := func(blk *schema.SchemaNestedBlock, err error) {
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		ret.BlockTypes = append(ret.BlockTypes, blk)
+	}
+
+	for _, name := range names {
+		ps := m[name]
+		path := path.WithAttributeName(name)
+		if ps == nil {
+			errs = append(errs, path.NewErrorf("schema is nil"))
+			continue
+		}
 		if ps.Elem == nil {
-			ret.Attributes = append(ret.Attributes, fromProviderSchemaAttribute(name, ps))
+			addAttribute(fromProviderSchemaAttribute(path, name, ps))
 			continue
 		}
 		if ps.Type == sdkschema.TypeMap {
@@ -32,30 +78,30 @@ func FromSchemaMap(m map[string]*sdkschema.Schema) *schema.SchemaBlock {
 				sch.Elem = &sdkschema.Schema{
 					Type: sdkschema.TypeString,
 				}
-				ret.Attributes = append(ret.Attributes, fromProviderSchemaAttribute(name, &sch))
+				addAttribute(fromProviderSchemaAttribute(path, name, &sch))
 				continue
 			}
 		}
 		switch ps.ConfigMode {
 		case sdkschema.SchemaConfigModeAttr:
-			ret.Attributes = append(ret.Attributes, fromProviderSchemaAttribute(name, ps))
+			addAttribute(fromProviderSchemaAttribute(path, name, ps))
 		case sdkschema.SchemaConfigModeBlock:
-			ret.BlockTypes = append(ret.BlockTypes, fromProviderSchemaBlock(name, ps))
+			addBlock(fromProviderSchemaBlock(path, name, ps))
 		default: // SchemaConfigModeAuto, or any other invalid value
 			if ps.Computed && !ps.Optional {
 				// Computed-only schemas are always handled as attributes,
 				// because they never appear in configuration.
-				ret.Attributes = append(ret.Attributes, fromProviderSchemaAttribute(name, ps))
+				addAttribute(fromProviderSchemaAttribute(path, name, ps))
 				continue
 			}
 			switch ps.Elem.(type) {
 			case *sdkschema.Schema, sdkschema.ValueType:
-				ret.Attributes = append(ret.Attributes, fromProviderSchemaAttribute(name, ps))
+				addAttribute(fromProviderSchemaAttribute(path, name, ps))
 			case *sdkschema.Resource:
-				ret.BlockTypes = append(ret.BlockTypes, fromProviderSchemaBlock(name, ps))
+				addBlock(fromProviderSchemaBlock(path, name, ps))
 			default:
 				// Should never happen for a valid schema
-				panic(fmt.Errorf("invalid Schema.Elem %#v; need *schema.Schema or *schema.Resource", ps.Elem))
+				errs = append(errs, path.NewErrorf("invalid Schema.Elem %#v; need *schema.Schema or *schema.Resource", ps.Elem))
 			}
 		}
 	}
@@ -68,10 +114,10 @@ func FromSchemaMap(m map[string]*sdkschema.Schema) *schema.SchemaBlock {
 		return ret.BlockTypes[i].TypeName < ret.BlockTypes[j].TypeName
 	})
 
-	return ret
+	return ret, errors.Join(errs...)
 }
 
-func fromProviderSchemaAttribute(name string, ps *sdkschema.Schema) *schema.SchemaAttribute {
+func fromProviderSchemaAttribute(path *tftypes.AttributePath, name string, ps *sdkschema.Schema) (*schema.SchemaAttribute, error) {
 	reqd := ps.Required
 	opt := ps.Optional
 	if reqd && ps.DefaultFunc != nil {
@@ -81,7 +127,10 @@ func fromProviderSchemaAttribute(name string, ps *sdkschema.Schema) *schema.Sche
 			opt = true
 		}
 	}
-	typ := fromProviderSchemaType(ps)
+	typ, err := fromProviderSchemaType(path, ps)
+	if err != nil {
+		return nil, err
+	}
 
 	return &schema.SchemaAttribute{
 		Name:     name,
@@ -94,14 +143,19 @@ func fromProviderSchemaAttribute(name string, ps *sdkschema.Schema) *schema.Sche
 		Default:   ps.Default,
 		Sensitive: ps.Sensitive,
 
+		Description:     sdkschema.SchemaDescriptionBuilder(ps),
+		DescriptionKind: descriptionKind(),
+
+		DeprecationMessage: ps.Deprecated,
+
 		ConflictsWith: ps.ConflictsWith,
 		ExactlyOneOf:  ps.ExactlyOneOf,
 		AtLeastOneOf:  ps.AtLeastOneOf,
 		RequiredWith:  ps.RequiredWith,
-	}
+	}, nil
 }
 
-func fromProviderSchemaBlock(name string, ps *sdkschema.Schema) *schema.SchemaNestedBlock {
+func fromProviderSchemaBlock(path *tftypes.AttributePath, name string, ps *sdkschema.Schema) (*schema.SchemaNestedBlock, error) {
 	ret := &schema.SchemaNestedBlock{
 		TypeName: name,
 		Required: &ps.Required,
@@ -109,13 +163,30 @@ func fromProviderSchemaBlock(name string, ps *sdkschema.Schema) *schema.SchemaNe
 		Computed: &ps.Computed,
 		ForceNew: &ps.ForceNew,
 
+		Description:     sdkschema.SchemaDescriptionBuilder(ps),
+		DescriptionKind: descriptionKind(),
+
+		DeprecationMessage: ps.Deprecated,
+
 		ConflictsWith: ps.ConflictsWith,
 		ExactlyOneOf:  ps.ExactlyOneOf,
 		AtLeastOneOf:  ps.AtLeastOneOf,
 		RequiredWith:  ps.RequiredWith,
 	}
 
-	if nested := FromResource(ps.Elem.(*sdkschema.Resource)); nested != nil {
+	res, ok := ps.Elem.(*sdkschema.Resource)
+	if !ok {
+		// Should never happen for a valid schema
+		return nil, path.NewErrorf("invalid Schema.Elem type %T; need *schema.Resource for a block", ps.Elem)
+	}
+
+	var errs []error
+
+	nested, err := fromResource(path, res)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if nested != nil {
 		ret.Block = nested.Block
 	}
 
@@ -128,7 +199,11 @@ func fromProviderSchemaBlock(name string, ps *sdkschema.Schema) *schema.SchemaNe
 		ret.Nesting = schema.SchemaNestedBlockNestingModeMap
 	default:
 		// Should never happen for a valid schema
-		panic(fmt.Errorf("invalid s.Type %s for s.Elem being resource", ps.Type))
+		errs = append(errs, path.NewErrorf("invalid s.Type %s for s.Elem being resource", ps.Type))
+	}
+
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
 	}
 
 	ret.MinItems = ps.MinItems
@@ -154,69 +229,155 @@ func fromProviderSchemaBlock(name string, ps *sdkschema.Schema) *schema.SchemaNe
 		ret.MaxItems = 0
 	}
 
-	return ret
+	return ret, nil
 }
 
-func fromProviderSchemaType(ps *sdkschema.Schema) cty.Type {
+func fromProviderSchemaType(path *tftypes.AttributePath, ps *sdkschema.Schema) (cty.Type, error) {
 	switch ps.Type {
 	case sdkschema.TypeString:
-		return cty.String
+		return cty.String, nil
 	case sdkschema.TypeBool:
-		return cty.Bool
+		return cty.Bool, nil
 	case sdkschema.TypeInt, sdkschema.TypeFloat:
-		return cty.Number
+		return cty.Number, nil
 	case sdkschema.TypeList, sdkschema.TypeSet, sdkschema.TypeMap:
 		var elemType cty.Type
 		switch set := ps.Elem.(type) {
 		case *sdkschema.Schema:
-			elemType = fromProviderSchemaType(set)
+			var err error
+			elemType, err = fromProviderSchemaType(path, set)
+			if err != nil {
+				return cty.NilType, err
+			}
 		case sdkschema.ValueType:
-			elemType = fromProviderSchemaType(&sdkschema.Schema{Type: set})
+			var err error
+			elemType, err = fromProviderSchemaType(path, &sdkschema.Schema{Type: set})
+			if err != nil {
+				return cty.NilType, err
+			}
 		case *sdkschema.Resource:
-			elemType = ImpliedType(FromResource(set).Block)
+			res, err := fromResource(path, set)
+			if err != nil {
+				return cty.NilType, err
+			}
+			elemType, err = res.Block.ImpliedType()
+			if err != nil {
+				return cty.NilType, path.NewError(err)
+			}
 		default:
 			if set != nil {
-				panic(fmt.Errorf("invalid Schema.Elem %#v; need *schema.Schema or *schema.Resource", ps.Elem))
+				return cty.NilType, path.NewErrorf("invalid Schema.Elem %#v; need *schema.Schema or *schema.Resource", ps.Elem)
 			}
 			elemType = cty.String
 		}
 		switch ps.Type {
 		case sdkschema.TypeList:
-			return cty.List(elemType)
+			return cty.List(elemType), nil
 		case sdkschema.TypeSet:
-			return cty.Set(elemType)
+			return cty.Set(elemType), nil
 		case sdkschema.TypeMap:
-			return cty.Map(elemType)
+			return cty.Map(elemType), nil
 		default:
-			panic("invalid collection type")
+			return cty.NilType, path.NewErrorf("invalid collection type")
 		}
 	default:
-		panic(fmt.Errorf("invalid Schema.Type %s", ps.Type))
+		return cty.NilType, path.NewErrorf("invalid Schema.Type %s", ps.Type)
 	}
 }
 
+// FromResource is like FromResourceE, but panics if the resource schema is invalid.
 func FromResource(res *sdkschema.Resource) *schema.Schema {
+	ret, err := FromResourceE(res)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+// FromResourceE converts the resource schema, collecting all the problems found in it.
+func FromResourceE(res *sdkschema.Resource) (*schema.Schema, error) {
+	return fromResource(tftypes.NewAttributePath(), res)
+}
+
+func fromResource(path *tftypes.AttributePath, res *sdkschema.Resource) (*schema.Schema, error) {
+	if res == nil {
+		return nil, path.NewErrorf("resource is nil")
+	}
+	block, err := fromSchemaMap(path, res.Schema)
+	if err != nil {
+		return nil, err
+	}
 	ret := &schema.Schema{
 		Version: int64(res.SchemaVersion),
-		Block:   FromSchemaMap(res.Schema),
+		Block:   block,
+
+		Description:     sdkschema.ResourceDescriptionBuilder(res),
+		DescriptionKind: descriptionKind(),
+
+		DeprecationMessage: res.DeprecationMessage,
 	}
-	return ret
+	return ret, nil
+}
+
+// descriptionKind returns the description kind that the SDK applies to all the descriptions of this provider.
+func descriptionKind() schema.StringKind {
+	if sdkschema.DescriptionKind == sdkschema.StringMarkdown {
+		return schema.StringKindMarkdown
+	}
+	return schema.StringKindPlain
 }
 
+// FromProvider is like FromProviderE, but panics if any schema of the provider is invalid.
 func FromProvider(p *sdkschema.Provider) *schema.ProviderSchema {
+	ret, err := FromProviderE(p)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+// FromProviderE converts the provider schema, including all its resources and data sources. Invalid resources or
+// data sources are left out of the result, while the problems of all of them are collected in the returned error.
+func FromProviderE(p *sdkschema.Provider) (*schema.ProviderSchema, error) {
+	var errs []error
+
+	providerBlock, err := FromSchemaMapE(p.Schema)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("converting provider schema: %w", err))
+	}
+
 	ret := &schema.ProviderSchema{
 		Provider: &schema.Schema{
-			Block: FromSchemaMap(p.Schema),
+			Block: providerBlock,
 		},
 		ResourceSchemas:   map[string]*schema.Schema{},
 		DataSourceSchemas: map[string]*schema.Schema{},
 	}
 
-	for name, res := range p.ResourcesMap {
-		ret.ResourceSchemas[name] = FromResource(res)
+	for _, name := range sortedKeys(p.ResourcesMap) {
+		sch, err := FromResourceE(p.ResourcesMap[name])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("converting resource schema (%s): %w", name, err))
+			continue
+		}
+		ret.ResourceSchemas[name] = sch
 	}
-	for name, res := range p.DataSourcesMap {
-		ret.DataSourceSchemas[name] = FromResource(res)
+	for _, name := range sortedKeys(p.DataSourcesMap) {
+		sch, err := FromResourceE(p.DataSourcesMap[name])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("converting datasource schema (%s): %w", name, err))
+			continue
+		}
+		ret.DataSourceSchemas[name] = sch
 	}
-	return ret
+	return ret, errors.Join(errs...)
+}
+
+func sortedKeys(m map[string]*sdkschema.Resource) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
@@ -2,6 +2,7 @@ package sdkv2
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -571,6 +572,39 @@ func TestFromSchemaMap(t *testing.T) {
 				BlockTypes: []*schema.SchemaNestedBlock{},
 			}),
 		},
+		"mistyped default value": {
+			map[string]*sdkschema.Schema{
+				"bool": {
+					Type:     sdkschema.TypeBool,
+					Optional: true,
+					Default:  "true",
+				},
+				"string": {
+					Type:     sdkschema.TypeString,
+					Optional: true,
+					Default:  1,
+				},
+			},
+			testSchema(&schema.SchemaBlock{
+				Attributes: []*schema.SchemaAttribute{
+					{
+						Name:     "bool",
+						Type:     ToPtr(cty.Bool),
+						Optional: true,
+						Default:  "true",
+						ForceNew: ToPtr(false),
+					},
+					{
+						Name:     "string",
+						Type:     ToPtr(cty.String),
+						Optional: true,
+						Default:  1,
+						ForceNew: ToPtr(false),
+					},
+				},
+				BlockTypes: []*schema.SchemaNestedBlock{},
+			}),
+		},
 		"cross attribute constraints": {
 			map[string]*sdkschema.Schema{
 				"a1": {
@@ -829,6 +863,88 @@ func TestFromSchemaMap(t *testing.T) {
 				},
 			}),
 		},
+		"description": {
+			map[string]*sdkschema.Schema{
+				"string": {
+					Type:        sdkschema.TypeString,
+					Optional:    true,
+					Description: "A string attribute",
+				},
+				"list": {
+					Type:     sdkschema.TypeList,
+					Optional: true,
+					Elem: &sdkschema.Resource{
+						Schema: map[string]*sdkschema.Schema{},
+					},
+					Description: "A list block",
+				},
+			},
+			testSchema(&schema.SchemaBlock{
+				Attributes: []*schema.SchemaAttribute{
+					{
+						Name:            "string",
+						Type:            ToPtr(cty.String),
+						Optional:        true,
+						ForceNew:        ToPtr(false),
+						Description:     "A string attribute",
+						DescriptionKind: schema.StringKindPlain,
+					},
+				},
+				BlockTypes: []*schema.SchemaNestedBlock{
+					{
+						TypeName:        "list",
+						Nesting:         schema.SchemaNestedBlockNestingModeList,
+						Block:           &schema.SchemaBlock{},
+						Optional:        ToPtr(true),
+						Required:        ToPtr(false),
+						Computed:        ToPtr(false),
+						ForceNew:        ToPtr(false),
+						Description:     "A list block",
+						DescriptionKind: schema.StringKindPlain,
+					},
+				},
+			}),
+		},
+		"deprecation": {
+			map[string]*sdkschema.Schema{
+				"string": {
+					Type:       sdkschema.TypeString,
+					Optional:   true,
+					Deprecated: "Use `new_string` instead",
+				},
+				"list": {
+					Type:     sdkschema.TypeList,
+					Optional: true,
+					Elem: &sdkschema.Resource{
+						Schema: map[string]*sdkschema.Schema{},
+					},
+					Deprecated: "Use `new_list` instead",
+				},
+			},
+			testSchema(&schema.SchemaBlock{
+				Attributes: []*schema.SchemaAttribute{
+					{
+						Name:               "string",
+						Type:               ToPtr(cty.String),
+						Optional:           true,
+						ForceNew:           ToPtr(false),
+						DeprecationMessage: "Use `new_string` instead",
+					},
+				},
+				BlockTypes: []*schema.SchemaNestedBlock{
+					{
+						TypeName:           "list",
+						Nesting:            schema.SchemaNestedBlockNestingModeList,
+						Block:              &schema.SchemaBlock{},
+						Optional:           ToPtr(true),
+						Required:           ToPtr(false),
+						Computed:           ToPtr(false),
+						ForceNew:           ToPtr(false),
+						DeprecationMessage: "Use `new_list` instead",
+					},
+				},
+			}),
+		},
 	}
 
 	for name, test := range tests {
@@ -907,6 +1023,23 @@ func TestFromResource(t *testing.T) {
 				},
 			}),
 		},
+		"description": {
+			&sdkschema.Resource{
+				Description: "A resource",
+			},
+			testResource(&schema.Schema{
+				Description:     "A resource",
+				DescriptionKind: schema.StringKindPlain,
+			}),
+		},
+		"deprecation": {
+			&sdkschema.Resource{
+				DeprecationMessage: "Use `new_resource` instead",
+			},
+			testResource(&schema.Schema{
+				DeprecationMessage: "Use `new_resource` instead",
+			}),
+		},
 	}
 
 	for name, test := range tests {
@@ -1022,3 +1155,174 @@ func TestFromProvider(t *testing.T) {
 func ToPtr[T any](v T) *T {
 	return &v
 }
+
+func TestFromSchemaMapE(t *testing.T) {
+	tests := map[string]struct {
+		Schema map[string]*sdkschema.Schema
+		Err    string
+	}{
+		"valid": {
+			Schema: map[string]*sdkschema.Schema{
+				"string": {
+					Type:     sdkschema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+		"invalid type": {
+			Schema: map[string]*sdkschema.Schema{
+				"invalid": {
+					Optional: true,
+				},
+			},
+			Err: `AttributeName("invalid"): invalid Schema.Type TypeInvalid`,
+		},
+		"invalid elem": {
+			Schema: map[string]*sdkschema.Schema{
+				"list": {
+					Type:     sdkschema.TypeList,
+					Optional: true,
+					Elem:     "foo",
+				},
+			},
+			Err: `AttributeName("list"): invalid Schema.Elem "foo"; need *schema.Schema or *schema.Resource`,
+		},
+		"invalid block type": {
+			Schema: map[string]*sdkschema.Schema{
+				"block": {
+					Type:     sdkschema.TypeString,
+					Optional: true,
+					Elem: &sdkschema.Resource{
+						Schema: map[string]*sdkschema.Schema{},
+					},
+				},
+			},
+			Err: `AttributeName("block"): invalid s.Type TypeString for s.Elem being resource`,
+		},
+		"invalid block elem": {
+			Schema: map[string]*sdkschema.Schema{
+				"block": {
+					Type:       sdkschema.TypeList,
+					Optional:   true,
+					ConfigMode: sdkschema.SchemaConfigModeBlock,
+					Elem: &sdkschema.Schema{
+						Type: sdkschema.TypeString,
+					},
+				},
+			},
+			Err: `AttributeName("block"): invalid Schema.Elem type *schema.Schema; need *schema.Resource for a block`,
+		},
+		"nested and multiple errors": {
+			Schema: map[string]*sdkschema.Schema{
+				"a": {
+					Optional: true,
+				},
+				"block": {
+					Type:     sdkschema.TypeList,
+					Optional: true,
+					Elem: &sdkschema.Resource{
+						Schema: map[string]*sdkschema.Schema{
+							"b": {
+								Type:     sdkschema.TypeList,
+								Optional: true,
+								Elem:     1,
+							},
+							"c": {
+								Optional: true,
+							},
+						},
+					},
+				},
+				"computed": {
+					Type:     sdkschema.TypeList,
+					Computed: true,
+					Elem: &sdkschema.Resource{
+						Schema: map[string]*sdkschema.Schema{
+							"d": {
+								Computed: true,
+							},
+						},
+					},
+				},
+			},
+			Err: `AttributeName("a"): invalid Schema.Type TypeInvalid
+AttributeName("block").AttributeName("b"): invalid Schema.Elem 1; need *schema.Schema or *schema.Resource
+AttributeName("block").AttributeName("c"): invalid Schema.Type TypeInvalid
+AttributeName("computed").AttributeName("d"): invalid Schema.Type TypeInvalid`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := FromSchemaMapE(test.Schema)
+			if test.Err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error %q, got none", test.Err)
+			}
+			if !strings.HasPrefix(err.Error(), test.Err) {
+				t.Fatalf("expected error %q, got %q", test.Err, err.Error())
+			}
+		})
+	}
+}
+
+func TestFromProviderE(t *testing.T) {
+	p := &sdkschema.Provider{
+		ResourcesMap: map[string]*sdkschema.Resource{
+			"valid": {
+				Schema: map[string]*sdkschema.Schema{
+					"a": {
+						Type:     sdkschema.TypeInt,
+						Required: true,
+					},
+				},
+			},
+			"invalid": {
+				Schema: map[string]*sdkschema.Schema{
+					"a": {
+						Required: true,
+					},
+				},
+			},
+			"nil": nil,
+		},
+		DataSourcesMap: map[string]*sdkschema.Resource{
+			"invalid": {
+				Schema: map[string]*sdkschema.Schema{
+					"b": {
+						Type:     sdkschema.TypeSet,
+						Computed: true,
+						Elem:     "foo",
+					},
+				},
+			},
+			"nil": nil,
+		},
+	}
+
+	got, err := FromProviderE(p)
+	wantErr := `converting resource schema (invalid): AttributeName("a"): invalid Schema.Type TypeInvalid
+converting resource schema (nil): resource is nil
+converting datasource schema (invalid): AttributeName("b"): invalid Schema.Elem "foo"; need *schema.Schema or *schema.Resource
+converting datasource schema (nil): resource is nil`
+	if err == nil || err.Error() != wantErr {
+		t.Fatalf("expected error %q, got %v", wantErr, err)
+	}
+	if _, ok := got.ResourceSchemas["valid"]; !ok {
+		t.Errorf("expected the valid resource to be converted")
+	}
+	if _, ok := got.ResourceSchemas["invalid"]; ok {
+		t.Errorf("expected the invalid resource to be omitted")
+	}
+	if _, ok := got.DataSourceSchemas["invalid"]; ok {
+		t.Errorf("expected the invalid data source to be omitted")
+	}
+	if _, ok := got.ResourceSchemas["nil"]; ok {
+		t.Errorf("expected the nil resource to be omitted")
+	}
+}
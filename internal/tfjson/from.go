@@ -0,0 +1,405 @@
+package tfjson
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/magodo/tfpluginschema/schema"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// FromProviderSchemasJSON converts the output of `terraform providers schema -json` to the provider schemas, keyed by the provider source address.
+// The tfpluginschema only properties are read from the "tfpluginschema" key, if any.
+func FromProviderSchemasJSON(b []byte) (map[string]*schema.ProviderSchema, error) {
+	var schemas ProviderSchemas
+	if err := json.Unmarshal(b, &schemas); err != nil {
+		return nil, fmt.Errorf("unmarshalling provider schemas: %v", err)
+	}
+	return FromProviderSchemas(&schemas)
+}
+
+// FromProviderSchemas converts the provider schemas to the ones defined in tfpluginschema, keyed by the provider source address.
+func FromProviderSchemas(schemas *ProviderSchemas) (map[string]*schema.ProviderSchema, error) {
+	if major, _, _ := strings.Cut(schemas.FormatVersion, "."); major != "1" {
+		return nil, fmt.Errorf("unsupported format version %q (expected %q)", schemas.FormatVersion, FormatVersion)
+	}
+
+	ret := map[string]*schema.ProviderSchema{}
+	for addr, p := range schemas.Schemas {
+		if p == nil {
+			return nil, fmt.Errorf("provider %s is null", addr)
+		}
+		ps, err := FromProvider(p)
+		if err != nil {
+			return nil, fmt.Errorf("converting provider %s: %v", addr, err)
+		}
+		ret[addr] = ps
+	}
+	return ret, nil
+}
+
+func FromProvider(p *Provider) (*schema.ProviderSchema, error) {
+	if p == nil {
+		return nil, fmt.Errorf("provider is null")
+	}
+	ret := &schema.ProviderSchema{
+		ResourceSchemas:          map[string]*schema.Schema{},
+		DataSourceSchemas:        map[string]*schema.Schema{},
+		EphemeralResourceSchemas: map[string]*schema.Schema{},
+	}
+
+	if p.Provider != nil {
+		sch, err := FromSchema(p.Provider)
+		if err != nil {
+			return nil, fmt.Errorf("converting provider schema: %v", err)
+		}
+		ret.Provider = sch
+	}
+	for name, res := range p.ResourceSchemas {
+		if res == nil {
+			return nil, fmt.Errorf("resource schema (%s) is null", name)
+		}
+		sch, err := FromSchema(res)
+		if err != nil {
+			return nil, fmt.Errorf("converting resource schema (%s): %v", name, err)
+		}
+		ret.ResourceSchemas[name] = sch
+	}
+	for name, ds := range p.DataSourceSchemas {
+		if ds == nil {
+			return nil, fmt.Errorf("datasource schema (%s) is null", name)
+		}
+		sch, err := FromSchema(ds)
+		if err != nil {
+			return nil, fmt.Errorf("converting datasource schema (%s): %v", name, err)
+		}
+		ret.DataSourceSchemas[name] = sch
+	}
+	for name, er := range p.EphemeralResourceSchemas {
+		if er == nil {
+			return nil, fmt.Errorf("ephemeral resource schema (%s) is null", name)
+		}
+		sch, err := FromSchema(er)
+		if err != nil {
+			return nil, fmt.Errorf("converting ephemeral resource schema (%s): %v", name, err)
+		}
+		ret.EphemeralResourceSchemas[name] = sch
+	}
+	for name, f := range p.Functions {
+		if f == nil {
+			return nil, fmt.Errorf("function (%s) is null", name)
+		}
+		if ret.Functions == nil {
+			ret.Functions = map[string]*schema.Function{}
+		}
+		fn, err := FromFunction(f)
+		if err != nil {
+			return nil, fmt.Errorf("converting function (%s): %v", name, err)
+		}
+		ret.Functions[name] = fn
+	}
+	return ret, nil
+}
+
+func FromFunction(f *FunctionSignature) (*schema.Function, error) {
+	if f == nil {
+		return nil, fmt.Errorf("function is null")
+	}
+	if len(f.ReturnType) == 0 {
+		return nil, fmt.Errorf("must have return_type set")
+	}
+	typ, err := ctyjson.UnmarshalType(f.ReturnType)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshalling return type to cty type: %v", err)
+	}
+	ret := &schema.Function{
+		ReturnType:         &typ,
+		Summary:            f.Summary,
+		Description:        f.Description,
+		DeprecationMessage: f.DeprecationMessage,
+	}
+	if ext := f.Extension; ext != nil {
+		ret.DescriptionKind, err = fromDescriptionKind(ext.DescriptionKind)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i, p := range f.Parameters {
+		param, err := fromFunctionParameter(p)
+		if err != nil {
+			return nil, fmt.Errorf("converting parameter %d: %v", i, err)
+		}
+		ret.Parameters = append(ret.Parameters, param)
+	}
+	if f.VariadicParameter != nil {
+		param, err := fromFunctionParameter(f.VariadicParameter)
+		if err != nil {
+			return nil, fmt.Errorf("converting variadic parameter: %v", err)
+		}
+		ret.VariadicParameter = param
+	}
+	return ret, nil
+}
+
+func fromFunctionParameter(p *FunctionParameter) (*schema.FunctionParameter, error) {
+	if p == nil {
+		return nil, fmt.Errorf("parameter is null")
+	}
+	if len(p.Type) == 0 {
+		return nil, fmt.Errorf("must have type set")
+	}
+	typ, err := ctyjson.UnmarshalType(p.Type)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshalling to cty type: %v", err)
+	}
+	ret := &schema.FunctionParameter{
+		Name:           p.Name,
+		Type:           &typ,
+		AllowNullValue: p.IsNullable,
+		Description:    p.Description,
+	}
+	if ext := p.Extension; ext != nil {
+		ret.AllowUnknownValues = ext.AllowUnknownValues
+		ret.DescriptionKind, err = fromDescriptionKind(ext.DescriptionKind)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+func FromSchema(s *Schema) (*schema.Schema, error) {
+	if s == nil {
+		return nil, fmt.Errorf("schema is null")
+	}
+	result := &schema.Schema{
+		Version: s.Version,
+	}
+	if s.Block == nil {
+		return result, nil
+	}
+
+	var err error
+	result.DescriptionKind, err = fromDescriptionKind(s.Block.DescriptionKind)
+	if err != nil {
+		return nil, err
+	}
+	result.Description = s.Block.Description
+	result.DeprecationMessage = deprecationMessage(s.Block.Deprecated)
+	if ext := s.Extension; ext != nil && ext.DeprecationMessage != "" {
+		result.DeprecationMessage = ext.DeprecationMessage
+	}
+
+	result.Block, err = fromBlock(tftypes.NewAttributePath(), s.Block)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func fromBlock(path *tftypes.AttributePath, b *Block) (*schema.SchemaBlock, error) {
+	result := &schema.SchemaBlock{}
+
+	for name, attr := range b.Attributes {
+		a, err := fromAttribute(path.WithAttributeName(name), name, attr)
+		if err != nil {
+			return nil, err
+		}
+		result.Attributes = append(result.Attributes, a)
+	}
+
+	for name, block := range b.BlockTypes {
+		nb, err := fromBlockType(path.WithAttributeName(name), name, block)
+		if err != nil {
+			return nil, err
+		}
+		result.BlockTypes = append(result.BlockTypes, nb)
+	}
+
+	sort.Slice(result.Attributes, func(i, j int) bool {
+		return result.Attributes[i].Name < result.Attributes[j].Name
+	})
+
+	sort.Slice(result.BlockTypes, func(i, j int) bool {
+		return result.BlockTypes[i].TypeName < result.BlockTypes[j].TypeName
+	})
+
+	return result, nil
+}
+
+func fromAttribute(path *tftypes.AttributePath, name string, a *Attribute) (*schema.SchemaAttribute, error) {
+	descKind, err := fromDescriptionKind(a.DescriptionKind)
+	if err != nil {
+		return nil, path.NewError(err)
+	}
+
+	schemaAttribute := &schema.SchemaAttribute{
+		Name:               name,
+		Required:           a.Required,
+		Optional:           a.Optional,
+		Computed:           a.Computed,
+		Sensitive:          a.Sensitive,
+		Description:        a.Description,
+		DescriptionKind:    descKind,
+		DeprecationMessage: deprecationMessage(a.Deprecated),
+	}
+
+	if ext := a.Extension; ext != nil {
+		if ext.DeprecationMessage != "" {
+			schemaAttribute.DeprecationMessage = ext.DeprecationMessage
+		}
+		schemaAttribute.ForceNew = ext.ForceNew
+		schemaAttribute.ConflictsWith = ext.ConflictsWith
+		schemaAttribute.ExactlyOneOf = ext.ExactlyOneOf
+		schemaAttribute.AtLeastOneOf = ext.AtLeastOneOf
+		schemaAttribute.RequiredWith = ext.RequiredWith
+		schemaAttribute.Validators = ext.Validators
+		schemaAttribute.RequiresReplaceIf = ext.RequiresReplaceIf
+		schemaAttribute.PlanModifiers = ext.PlanModifiers
+	}
+
+	if a.AttributeNestedType == nil {
+		if len(a.AttributeType) == 0 {
+			return nil, path.NewErrorf("must have type or nested_type set")
+		}
+		typ, err := ctyjson.UnmarshalType(a.AttributeType)
+		if err != nil {
+			return nil, path.NewErrorf("unmarshalling to cty type: %v", err)
+		}
+		schemaAttribute.Type = &typ
+		if err := unmarshalDefault(schemaAttribute, a.Extension); err != nil {
+			return nil, path.NewError(err)
+		}
+		return schemaAttribute, nil
+	}
+
+	object := &schema.SchemaObject{}
+	switch nm := a.AttributeNestedType.NestingMode; nm {
+	case nestingModeSingle:
+		object.Nesting = schema.SchemaObjectNestingModeSingle
+	case nestingModeList:
+		object.Nesting = schema.SchemaObjectNestingModeList
+	case nestingModeSet:
+		object.Nesting = schema.SchemaObjectNestingModeSet
+	case nestingModeMap:
+		object.Nesting = schema.SchemaObjectNestingModeMap
+	default:
+		return nil, path.NewErrorf("unrecognized nesting mode %q", nm)
+	}
+
+	for nestedName, nestedA := range a.AttributeNestedType.Attributes {
+		nestedSchemaAttribute, err := fromAttribute(path.WithAttributeName(nestedName), nestedName, nestedA)
+		if err != nil {
+			return nil, err
+		}
+		object.Attributes = append(object.Attributes, nestedSchemaAttribute)
+	}
+
+	sort.Slice(object.Attributes, func(i, j int) bool {
+		return object.Attributes[i].Name < object.Attributes[j].Name
+	})
+
+	schemaAttribute.NestedType = object
+	if err := unmarshalDefault(schemaAttribute, a.Extension); err != nil {
+		return nil, path.NewError(err)
+	}
+
+	return schemaAttribute, nil
+}
+
+func unmarshalDefault(a *schema.SchemaAttribute, ext *AttributeExtension) error {
+	if ext == nil || len(ext.Default) == 0 {
+		return nil
+	}
+	ty, err := a.ImpliedType()
+	if err != nil {
+		return err
+	}
+	val, err := ctyjson.Unmarshal(ext.Default, ty)
+	if err != nil {
+		return fmt.Errorf("unmarshalling default: %v", err)
+	}
+	return a.SetDefaultValue(val)
+}
+
+func fromBlockType(path *tftypes.AttributePath, name string, b *BlockType) (*schema.SchemaNestedBlock, error) {
+	schemaNestedBlock := &schema.SchemaNestedBlock{
+		TypeName: name,
+		MinItems: b.MinItems,
+		MaxItems: b.MaxItems,
+	}
+
+	switch nm := b.NestingMode; nm {
+	case nestingModeSingle:
+		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeSingle
+	case nestingModeList:
+		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeList
+	case nestingModeSet:
+		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeSet
+	case nestingModeMap:
+		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeMap
+	case nestingModeGroup:
+		schemaNestedBlock.Nesting = schema.SchemaNestedBlockNestingModeGroup
+	default:
+		return nil, path.NewErrorf("unrecognized nesting mode %q", nm)
+	}
+
+	if ext := b.Extension; ext != nil {
+		schemaNestedBlock.Required = ext.Required
+		schemaNestedBlock.Optional = ext.Optional
+		schemaNestedBlock.Computed = ext.Computed
+		schemaNestedBlock.ForceNew = ext.ForceNew
+		schemaNestedBlock.ConflictsWith = ext.ConflictsWith
+		schemaNestedBlock.ExactlyOneOf = ext.ExactlyOneOf
+		schemaNestedBlock.AtLeastOneOf = ext.AtLeastOneOf
+		schemaNestedBlock.RequiredWith = ext.RequiredWith
+		schemaNestedBlock.Validators = ext.Validators
+		schemaNestedBlock.RequiresReplaceIf = ext.RequiresReplaceIf
+		schemaNestedBlock.PlanModifiers = ext.PlanModifiers
+	}
+
+	if b.Block == nil {
+		schemaNestedBlock.Block = &schema.SchemaBlock{}
+		return schemaNestedBlock, nil
+	}
+
+	descKind, err := fromDescriptionKind(b.Block.DescriptionKind)
+	if err != nil {
+		return nil, path.NewError(err)
+	}
+	schemaNestedBlock.Description = b.Block.Description
+	schemaNestedBlock.DescriptionKind = descKind
+	schemaNestedBlock.DeprecationMessage = deprecationMessage(b.Block.Deprecated)
+	if ext := b.Extension; ext != nil && ext.DeprecationMessage != "" {
+		schemaNestedBlock.DeprecationMessage = ext.DeprecationMessage
+	}
+
+	block, err := fromBlock(path, b.Block)
+	if err != nil {
+		return nil, err
+	}
+	schemaNestedBlock.Block = block
+
+	return schemaNestedBlock, nil
+}
+
+func fromDescriptionKind(kind string) (schema.StringKind, error) {
+	switch kind {
+	case "", descriptionKindPlain:
+		return schema.StringKindPlain, nil
+	case descriptionKindMarkdown:
+		return schema.StringKindMarkdown, nil
+	default:
+		return 0, fmt.Errorf("unrecognized description kind %q", kind)
+	}
+}
+
+func deprecationMessage(deprecated bool) string {
+	if deprecated {
+		return schema.DefaultDeprecationMessage
+	}
+	return ""
+}
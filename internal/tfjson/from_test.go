@@ -0,0 +1,303 @@
+package tfjson_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/magodo/tfpluginschema/internal/tfjson"
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var (
+	typeComparer = cmp.Comparer(cty.Type.Equals)
+	equateEmpty  = cmpopts.EquateEmpty()
+)
+
+const providerSchemasJSON = `{
+  "format_version": "1.0",
+  "provider_schemas": {
+    "registry.terraform.io/hashicorp/foo": {
+      "provider": {
+        "version": 0,
+        "block": {
+          "attributes": {
+            "token": {
+              "type": "string",
+              "description_kind": "plain",
+              "optional": true,
+              "sensitive": true
+            }
+          },
+          "description_kind": "plain"
+        }
+      },
+      "resource_schemas": {
+        "foo_resource": {
+          "version": 1,
+          "block": {
+            "attributes": {
+              "id": {
+                "type": "string",
+                "description_kind": "plain",
+                "computed": true
+              },
+              "tags": {
+                "type": ["map", "string"],
+                "description": "The tags",
+                "description_kind": "markdown",
+                "optional": true,
+                "deprecated": true
+              },
+              "nested": {
+                "nested_type": {
+                  "attributes": {
+                    "object": {
+                      "type": ["object", {"a": "bool", "b": ["list", "number"]}],
+                      "description_kind": "plain",
+                      "required": true
+                    }
+                  },
+                  "nesting_mode": "list"
+                },
+                "description_kind": "plain",
+                "optional": true
+              }
+            },
+            "block_types": {
+              "timeouts": {
+                "nesting_mode": "single",
+                "block": {
+                  "attributes": {
+                    "create": {
+                      "type": "string",
+                      "description_kind": "plain",
+                      "optional": true
+                    }
+                  },
+                  "description_kind": "plain"
+                }
+              },
+              "rule": {
+                "nesting_mode": "set",
+                "block": {
+                  "description": "A rule",
+                  "description_kind": "plain",
+                  "deprecated": true
+                },
+                "min_items": 1,
+                "max_items": 3
+              }
+            },
+            "description": "A resource",
+            "description_kind": "plain"
+          }
+        }
+      },
+      "data_source_schemas": {
+        "foo_data": {
+          "version": 0,
+          "block": {
+            "attributes": {
+              "any": {
+                "type": "dynamic",
+                "description_kind": "plain",
+                "computed": true
+              }
+            },
+            "block_types": {
+              "group": {
+                "nesting_mode": "group",
+                "block": {
+                  "description_kind": "plain"
+                }
+              }
+            },
+            "description_kind": "plain"
+          }
+        }
+      }
+    },
+    "registry.terraform.io/hashicorp/bar": {
+      "provider": {
+        "version": 0,
+        "block": {
+          "description_kind": "plain"
+        }
+      }
+    }
+  }
+}`
+
+func TestFromProviderSchemasJSON(t *testing.T) {
+	got, err := tfjson.FromProviderSchemasJSON([]byte(providerSchemasJSON))
+	require.NoError(t, err)
+
+	want := map[string]*schema.ProviderSchema{
+		"registry.terraform.io/hashicorp/foo": {
+			Provider: &schema.Schema{
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{
+							Name:      "token",
+							Type:      &cty.String,
+							Optional:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+			ResourceSchemas: map[string]*schema.Schema{
+				"foo_resource": {
+					Version:     1,
+					Description: "A resource",
+					Block: &schema.SchemaBlock{
+						Attributes: []*schema.SchemaAttribute{
+							{
+								Name:     "id",
+								Type:     &cty.String,
+								Computed: true,
+							},
+							{
+								Name:     "nested",
+								Optional: true,
+								NestedType: &schema.SchemaObject{
+									Nesting: schema.SchemaObjectNestingModeList,
+									Attributes: []*schema.SchemaAttribute{
+										{
+											Name:     "object",
+											Type:     ToPtr(cty.Object(map[string]cty.Type{"a": cty.Bool, "b": cty.List(cty.Number)})),
+											Required: true,
+										},
+									},
+								},
+							},
+							{
+								Name:               "tags",
+								Type:               ToPtr(cty.Map(cty.String)),
+								Optional:           true,
+								Description:        "The tags",
+								DescriptionKind:    schema.StringKindMarkdown,
+								DeprecationMessage: schema.DefaultDeprecationMessage,
+							},
+						},
+						BlockTypes: []*schema.SchemaNestedBlock{
+							{
+								TypeName:           "rule",
+								Nesting:            schema.SchemaNestedBlockNestingModeSet,
+								MinItems:           1,
+								MaxItems:           3,
+								Description:        "A rule",
+								DeprecationMessage: schema.DefaultDeprecationMessage,
+								Block:              &schema.SchemaBlock{},
+							},
+							{
+								TypeName: "timeouts",
+								Nesting:  schema.SchemaNestedBlockNestingModeSingle,
+								Block: &schema.SchemaBlock{
+									Attributes: []*schema.SchemaAttribute{
+										{
+											Name:     "create",
+											Type:     &cty.String,
+											Optional: true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			DataSourceSchemas: map[string]*schema.Schema{
+				"foo_data": {
+					Block: &schema.SchemaBlock{
+						Attributes: []*schema.SchemaAttribute{
+							{
+								Name:     "any",
+								Type:     &cty.DynamicPseudoType,
+								Computed: true,
+							},
+						},
+						BlockTypes: []*schema.SchemaNestedBlock{
+							{
+								TypeName: "group",
+								Nesting:  schema.SchemaNestedBlockNestingModeGroup,
+								Block:    &schema.SchemaBlock{},
+							},
+						},
+					},
+				},
+			},
+		},
+		"registry.terraform.io/hashicorp/bar": {
+			Provider: &schema.Schema{
+				Block: &schema.SchemaBlock{},
+			},
+		},
+	}
+
+	if !cmp.Equal(got, want, equateEmpty, typeComparer) {
+		t.Error(cmp.Diff(got, want, equateEmpty, typeComparer))
+	}
+}
+
+func TestFromProviderSchemasJSON_Error(t *testing.T) {
+	cases := map[string]struct {
+		input string
+		err   string
+	}{
+		"invalid json": {
+			input: `{`,
+			err:   "unmarshalling provider schemas: unexpected end of JSON input",
+		},
+		"unsupported format version": {
+			input: `{"format_version": "2.0"}`,
+			err:   `unsupported format version "2.0" (expected "1.0")`,
+		},
+		"invalid nesting mode": {
+			input: `{"format_version": "1.0", "provider_schemas": {"foo": {"resource_schemas": {"foo_res": {"block": {"block_types": {"blk": {"nesting_mode": "tuple"}}}}}}}}`,
+			err:   `converting provider foo: converting resource schema (foo_res): AttributeName("blk"): unrecognized nesting mode "tuple"`,
+		},
+		"invalid type": {
+			input: `{"format_version": "1.0", "provider_schemas": {"foo": {"provider": {"block": {"attributes": {"attr": {"type": "foo"}}}}}}}`,
+			err:   `converting provider foo: converting provider schema: AttributeName("attr"): unmarshalling to cty type: invalid primitive type name "foo"`,
+		},
+		"null provider": {
+			input: `{"format_version": "1.0", "provider_schemas": {"foo": null}}`,
+			err:   `provider foo is null`,
+		},
+		"null resource schema": {
+			input: `{"format_version": "1.0", "provider_schemas": {"foo": {"resource_schemas": {"foo_res": null}}}}`,
+			err:   `converting provider foo: resource schema (foo_res) is null`,
+		},
+		"null data source schema": {
+			input: `{"format_version": "1.0", "provider_schemas": {"foo": {"data_source_schemas": {"foo_ds": null}}}}`,
+			err:   `converting provider foo: datasource schema (foo_ds) is null`,
+		},
+		"null ephemeral resource schema": {
+			input: `{"format_version": "1.0", "provider_schemas": {"foo": {"ephemeral_resource_schemas": {"foo_eph": null}}}}`,
+			err:   `converting provider foo: ephemeral resource schema (foo_eph) is null`,
+		},
+		"null function": {
+			input: `{"format_version": "1.0", "provider_schemas": {"foo": {"functions": {"bar": null}}}}`,
+			err:   `converting provider foo: function (bar) is null`,
+		},
+		"null function parameter": {
+			input: `{"format_version": "1.0", "provider_schemas": {"foo": {"functions": {"bar": {"return_type": "string", "parameters": [null]}}}}}`,
+			err:   `converting provider foo: converting function (bar): converting parameter 0: parameter is null`,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tfjson.FromProviderSchemasJSON([]byte(tt.input))
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func ToPtr[T any](v T) *T {
+	return &v
+}
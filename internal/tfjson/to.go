@@ -0,0 +1,348 @@
+package tfjson
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/magodo/tfpluginschema/schema"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// ToProviderSchemasJSON converts the provider schemas, keyed by the provider source address, to the output format of `terraform providers schema -json`.
+// The tfpluginschema only properties are written under the "tfpluginschema" key only if withExtensions is true.
+func ToProviderSchemasJSON(schemas map[string]*schema.ProviderSchema, withExtensions bool) ([]byte, error) {
+	out, err := ToProviderSchemas(schemas, withExtensions)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(out)
+}
+
+// ToProviderSchemas converts the provider schemas, keyed by the provider source address, to the provider schemas of the Terraform core JSON format.
+func ToProviderSchemas(schemas map[string]*schema.ProviderSchema, withExtensions bool) (*ProviderSchemas, error) {
+	ret := &ProviderSchemas{
+		FormatVersion: FormatVersion,
+		Schemas:       map[string]*Provider{},
+	}
+	for addr, ps := range schemas {
+		p, err := ToProvider(ps, withExtensions)
+		if err != nil {
+			return nil, fmt.Errorf("converting provider %s: %v", addr, err)
+		}
+		ret.Schemas[addr] = p
+	}
+	return ret, nil
+}
+
+func ToProvider(ps *schema.ProviderSchema, withExtensions bool) (*Provider, error) {
+	ret := &Provider{
+		ResourceSchemas:          map[string]*Schema{},
+		DataSourceSchemas:        map[string]*Schema{},
+		EphemeralResourceSchemas: map[string]*Schema{},
+	}
+
+	if ps.Provider != nil {
+		sch, err := ToSchema(ps.Provider, withExtensions)
+		if err != nil {
+			return nil, fmt.Errorf("converting provider schema: %v", err)
+		}
+		ret.Provider = sch
+	}
+	for name, res := range ps.ResourceSchemas {
+		sch, err := ToSchema(res, withExtensions)
+		if err != nil {
+			return nil, fmt.Errorf("converting resource schema (%s): %v", name, err)
+		}
+		ret.ResourceSchemas[name] = sch
+	}
+	for name, ds := range ps.DataSourceSchemas {
+		sch, err := ToSchema(ds, withExtensions)
+		if err != nil {
+			return nil, fmt.Errorf("converting datasource schema (%s): %v", name, err)
+		}
+		ret.DataSourceSchemas[name] = sch
+	}
+	for name, er := range ps.EphemeralResourceSchemas {
+		sch, err := ToSchema(er, withExtensions)
+		if err != nil {
+			return nil, fmt.Errorf("converting ephemeral resource schema (%s): %v", name, err)
+		}
+		ret.EphemeralResourceSchemas[name] = sch
+	}
+	for name, f := range ps.Functions {
+		if ret.Functions == nil {
+			ret.Functions = map[string]*FunctionSignature{}
+		}
+		fn, err := ToFunction(f, withExtensions)
+		if err != nil {
+			return nil, fmt.Errorf("converting function (%s): %v", name, err)
+		}
+		ret.Functions[name] = fn
+	}
+	return ret, nil
+}
+
+func ToFunction(f *schema.Function, withExtensions bool) (*FunctionSignature, error) {
+	if f.ReturnType == nil {
+		return nil, fmt.Errorf("return type is nil")
+	}
+	b, err := f.ReturnType.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("marshalling return type: %v", err)
+	}
+	ret := &FunctionSignature{
+		Description:        f.Description,
+		Summary:            f.Summary,
+		DeprecationMessage: f.DeprecationMessage,
+		ReturnType:         b,
+	}
+	if withExtensions && f.DescriptionKind != schema.StringKindPlain {
+		ret.Extension = &FunctionSignatureExtension{
+			DescriptionKind: toDescriptionKind(f.DescriptionKind),
+		}
+	}
+	for i, p := range f.Parameters {
+		param, err := toFunctionParameter(p, withExtensions)
+		if err != nil {
+			return nil, fmt.Errorf("converting parameter %d: %v", i, err)
+		}
+		ret.Parameters = append(ret.Parameters, param)
+	}
+	if f.VariadicParameter != nil {
+		param, err := toFunctionParameter(f.VariadicParameter, withExtensions)
+		if err != nil {
+			return nil, fmt.Errorf("converting variadic parameter: %v", err)
+		}
+		ret.VariadicParameter = param
+	}
+	return ret, nil
+}
+
+func toFunctionParameter(p *schema.FunctionParameter, withExtensions bool) (*FunctionParameter, error) {
+	if p.Type == nil {
+		return nil, fmt.Errorf("type is nil")
+	}
+	b, err := p.Type.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("marshalling type: %v", err)
+	}
+	ret := &FunctionParameter{
+		Name:        p.Name,
+		Description: p.Description,
+		IsNullable:  p.AllowNullValue,
+		Type:        b,
+	}
+	if withExtensions && (p.DescriptionKind != schema.StringKindPlain || p.AllowUnknownValues) {
+		ret.Extension = &FunctionParameterExtension{
+			AllowUnknownValues: p.AllowUnknownValues,
+		}
+		if p.DescriptionKind != schema.StringKindPlain {
+			ret.Extension.DescriptionKind = toDescriptionKind(p.DescriptionKind)
+		}
+	}
+	return ret, nil
+}
+
+func ToSchema(s *schema.Schema, withExtensions bool) (*Schema, error) {
+	block, err := toBlock(tftypes.NewAttributePath(), s.Block, withExtensions)
+	if err != nil {
+		return nil, err
+	}
+	block.Description = s.Description
+	block.DescriptionKind = toDescriptionKind(s.DescriptionKind)
+	block.Deprecated = s.DeprecationMessage != ""
+
+	ret := &Schema{
+		Version: s.Version,
+		Block:   block,
+	}
+	if withExtensions && s.DeprecationMessage != "" {
+		ret.Extension = &SchemaExtension{
+			DeprecationMessage: s.DeprecationMessage,
+		}
+	}
+	return ret, nil
+}
+
+func toBlock(path *tftypes.AttributePath, b *schema.SchemaBlock, withExtensions bool) (*Block, error) {
+	result := &Block{
+		DescriptionKind: descriptionKindPlain,
+	}
+	if b == nil {
+		return result, nil
+	}
+
+	for _, attr := range b.Attributes {
+		if result.Attributes == nil {
+			result.Attributes = map[string]*Attribute{}
+		}
+		a, err := toAttribute(path.WithAttributeName(attr.Name), attr, withExtensions)
+		if err != nil {
+			return nil, err
+		}
+		result.Attributes[attr.Name] = a
+	}
+
+	for _, block := range b.BlockTypes {
+		if result.BlockTypes == nil {
+			result.BlockTypes = map[string]*BlockType{}
+		}
+		nb, err := toBlockType(path.WithAttributeName(block.TypeName), block, withExtensions)
+		if err != nil {
+			return nil, err
+		}
+		result.BlockTypes[block.TypeName] = nb
+	}
+
+	return result, nil
+}
+
+func toAttribute(path *tftypes.AttributePath, a *schema.SchemaAttribute, withExtensions bool) (*Attribute, error) {
+	attribute := &Attribute{
+		Required:        a.Required,
+		Optional:        a.Optional,
+		Computed:        a.Computed,
+		Sensitive:       a.Sensitive,
+		Description:     a.Description,
+		DescriptionKind: toDescriptionKind(a.DescriptionKind),
+		Deprecated:      a.DeprecationMessage != "",
+	}
+
+	if withExtensions {
+		ext := &AttributeExtension{
+			DeprecationMessage: a.DeprecationMessage,
+			ForceNew:           a.ForceNew,
+			ConflictsWith:      a.ConflictsWith,
+			ExactlyOneOf:       a.ExactlyOneOf,
+			AtLeastOneOf:       a.AtLeastOneOf,
+			RequiredWith:       a.RequiredWith,
+			Validators:         a.Validators,
+			RequiresReplaceIf:  a.RequiresReplaceIf,
+			PlanModifiers:      a.PlanModifiers,
+		}
+		if a.Default != nil {
+			b, err := marshalDefault(a)
+			if err != nil {
+				return nil, path.NewErrorf("marshalling default: %v", err)
+			}
+			ext.Default = b
+		}
+		if !reflect.ValueOf(*ext).IsZero() {
+			attribute.Extension = ext
+		}
+	}
+
+	if a.NestedType == nil {
+		if a.Type == nil {
+			return nil, path.NewErrorf("must have Type or NestedType set")
+		}
+		b, err := ctyjson.MarshalType(*a.Type)
+		if err != nil {
+			return nil, path.NewErrorf("marshalling cty type: %v", err)
+		}
+		attribute.AttributeType = b
+		return attribute, nil
+	}
+
+	object := &NestedType{
+		Attributes: map[string]*Attribute{},
+	}
+	switch nm := a.NestedType.Nesting; nm {
+	case schema.SchemaObjectNestingModeSingle:
+		object.NestingMode = nestingModeSingle
+	case schema.SchemaObjectNestingModeList:
+		object.NestingMode = nestingModeList
+	case schema.SchemaObjectNestingModeSet:
+		object.NestingMode = nestingModeSet
+	case schema.SchemaObjectNestingModeMap:
+		object.NestingMode = nestingModeMap
+	default:
+		return nil, path.NewErrorf("unrecognized nesting mode %v", nm)
+	}
+
+	for _, nestedA := range a.NestedType.Attributes {
+		nestedAttribute, err := toAttribute(path.WithAttributeName(nestedA.Name), nestedA, withExtensions)
+		if err != nil {
+			return nil, err
+		}
+		object.Attributes[nestedA.Name] = nestedAttribute
+	}
+
+	attribute.AttributeNestedType = object
+
+	return attribute, nil
+}
+
+func toBlockType(path *tftypes.AttributePath, b *schema.SchemaNestedBlock, withExtensions bool) (*BlockType, error) {
+	blockType := &BlockType{
+		MinItems: b.MinItems,
+		MaxItems: b.MaxItems,
+	}
+
+	switch nm := b.Nesting; nm {
+	case schema.SchemaNestedBlockNestingModeSingle:
+		blockType.NestingMode = nestingModeSingle
+	case schema.SchemaNestedBlockNestingModeList:
+		blockType.NestingMode = nestingModeList
+	case schema.SchemaNestedBlockNestingModeSet:
+		blockType.NestingMode = nestingModeSet
+	case schema.SchemaNestedBlockNestingModeMap:
+		blockType.NestingMode = nestingModeMap
+	case schema.SchemaNestedBlockNestingModeGroup:
+		blockType.NestingMode = nestingModeGroup
+	default:
+		return nil, path.NewErrorf("unrecognized nesting mode %v", nm)
+	}
+
+	if withExtensions {
+		ext := &BlockTypeExtension{
+			DeprecationMessage: b.DeprecationMessage,
+			Required:           b.Required,
+			Optional:           b.Optional,
+			Computed:           b.Computed,
+			ForceNew:           b.ForceNew,
+			ConflictsWith:      b.ConflictsWith,
+			ExactlyOneOf:       b.ExactlyOneOf,
+			AtLeastOneOf:       b.AtLeastOneOf,
+			RequiredWith:       b.RequiredWith,
+			Validators:         b.Validators,
+			RequiresReplaceIf:  b.RequiresReplaceIf,
+			PlanModifiers:      b.PlanModifiers,
+		}
+		if !reflect.ValueOf(*ext).IsZero() {
+			blockType.Extension = ext
+		}
+	}
+
+	block, err := toBlock(path, b.Block, withExtensions)
+	if err != nil {
+		return nil, err
+	}
+	block.Description = b.Description
+	block.DescriptionKind = toDescriptionKind(b.DescriptionKind)
+	block.Deprecated = b.DeprecationMessage != ""
+	blockType.Block = block
+
+	return blockType, nil
+}
+
+func toDescriptionKind(kind schema.StringKind) string {
+	if kind == schema.StringKindMarkdown {
+		return descriptionKindMarkdown
+	}
+	return descriptionKindPlain
+}
+
+func marshalDefault(a *schema.SchemaAttribute) ([]byte, error) {
+	ty, err := a.ImpliedType()
+	if err != nil {
+		return nil, err
+	}
+	val, err := a.DefaultValue()
+	if err != nil {
+		return nil, err
+	}
+	return ctyjson.Marshal(val, ty)
+}
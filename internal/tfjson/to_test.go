@@ -0,0 +1,205 @@
+package tfjson_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/magodo/tfpluginschema/internal/tfjson"
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var testProviderSchemas = map[string]*schema.ProviderSchema{
+	"registry.terraform.io/hashicorp/foo": {
+		Provider: &schema.Schema{
+			Block: &schema.SchemaBlock{
+				Attributes: []*schema.SchemaAttribute{
+					{
+						Name:      "token",
+						Type:      &cty.String,
+						Optional:  true,
+						Sensitive: true,
+					},
+				},
+			},
+		},
+		ResourceSchemas: map[string]*schema.Schema{
+			"foo_resource": {
+				Version:            1,
+				Description:        "A resource",
+				DeprecationMessage: "Use foo_new_resource instead",
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{
+							Name:     "nested",
+							Optional: true,
+							NestedType: &schema.SchemaObject{
+								Nesting: schema.SchemaObjectNestingModeList,
+								Attributes: []*schema.SchemaAttribute{
+									{
+										Name:     "object",
+										Type:     ToPtr(cty.Object(map[string]cty.Type{"a": cty.Bool})),
+										Required: true,
+									},
+								},
+							},
+						},
+						{
+							Name:               "tags",
+							Type:               ToPtr(cty.Map(cty.String)),
+							Optional:           true,
+							Description:        "The `tags`",
+							DescriptionKind:    schema.StringKindMarkdown,
+							DeprecationMessage: "Use labels instead",
+							Default:            map[string]interface{}{"env": "prod"},
+							ForceNew:           ToPtr(true),
+							ConflictsWith:      []string{"labels"},
+						},
+					},
+					BlockTypes: []*schema.SchemaNestedBlock{
+						{
+							TypeName:     "rule",
+							Nesting:      schema.SchemaNestedBlockNestingModeSet,
+							MinItems:     1,
+							MaxItems:     3,
+							Description:  "A rule",
+							Required:     ToPtr(true),
+							Optional:     ToPtr(false),
+							Computed:     ToPtr(false),
+							ForceNew:     ToPtr(false),
+							ExactlyOneOf: []string{"rule", "rules"},
+							Block:        &schema.SchemaBlock{},
+						},
+					},
+				},
+			},
+		},
+		Functions: map[string]*schema.Function{
+			"parse_id": {
+				Parameters: []*schema.FunctionParameter{
+					{
+						Name:            "id",
+						Type:            &cty.String,
+						AllowNullValue:  true,
+						Description:     "The `id`",
+						DescriptionKind: schema.StringKindMarkdown,
+					},
+				},
+				VariadicParameter: &schema.FunctionParameter{
+					Name:               "segments",
+					Type:               &cty.Number,
+					AllowUnknownValues: true,
+				},
+				ReturnType:         ToPtr(cty.List(cty.String)),
+				Summary:            "Parse an id",
+				Description:        "Parse a resource id",
+				DeprecationMessage: "Use parse_resource_id instead",
+			},
+		},
+	},
+}
+
+const testProviderSchemasJSON = `{
+  "format_version": "1.0",
+  "provider_schemas": {
+    "registry.terraform.io/hashicorp/foo": {
+      "provider": {
+        "version": 0,
+        "block": {
+          "attributes": {
+            "token": {
+              "type": "string",
+              "description_kind": "plain",
+              "optional": true,
+              "sensitive": true
+            }
+          },
+          "description_kind": "plain"
+        }
+      },
+      "resource_schemas": {
+        "foo_resource": {
+          "version": 1,
+          "block": {
+            "attributes": {
+              "nested": {
+                "nested_type": {
+                  "attributes": {
+                    "object": {
+                      "type": ["object", {"a": "bool"}],
+                      "description_kind": "plain",
+                      "required": true
+                    }
+                  },
+                  "nesting_mode": "list"
+                },
+                "description_kind": "plain",
+                "optional": true
+              },
+              "tags": {
+                "type": ["map", "string"],
+                "description": "The ` + "`tags`" + `",
+                "description_kind": "markdown",
+                "deprecated": true,
+                "optional": true
+              }
+            },
+            "block_types": {
+              "rule": {
+                "nesting_mode": "set",
+                "block": {
+                  "description": "A rule",
+                  "description_kind": "plain"
+                },
+                "min_items": 1,
+                "max_items": 3
+              }
+            },
+            "description": "A resource",
+            "description_kind": "plain",
+            "deprecated": true
+          }
+        }
+      },
+      "functions": {
+        "parse_id": {
+          "description": "Parse a resource id",
+          "summary": "Parse an id",
+          "deprecation_message": "Use parse_resource_id instead",
+          "return_type": ["list", "string"],
+          "parameters": [
+            {
+              "name": "id",
+              "description": "The ` + "`id`" + `",
+              "is_nullable": true,
+              "type": "string"
+            }
+          ],
+          "variadic_parameter": {
+            "name": "segments",
+            "type": "number"
+          }
+        }
+      }
+    }
+  }
+}`
+
+func TestToProviderSchemasJSON(t *testing.T) {
+	b, err := tfjson.ToProviderSchemasJSON(testProviderSchemas, false)
+	require.NoError(t, err)
+	require.JSONEq(t, testProviderSchemasJSON, string(b))
+}
+
+func TestToProviderSchemasJSON_RoundTrip(t *testing.T) {
+	b, err := tfjson.ToProviderSchemasJSON(testProviderSchemas, true)
+	require.NoError(t, err)
+
+	got, err := tfjson.FromProviderSchemasJSON(b)
+	require.NoError(t, err)
+
+	if !cmp.Equal(got, testProviderSchemas, equateEmpty, typeComparer) {
+		t.Error(cmp.Diff(got, testProviderSchemas, equateEmpty, typeComparer))
+	}
+}
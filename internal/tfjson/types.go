@@ -0,0 +1,147 @@
+package tfjson
+
+// The JSON format of `terraform providers schema -json`.
+// Referencing: github.com/hashicorp/terraform/internal/command/jsonprovider@v1.10.0
+
+import (
+	"encoding/json"
+
+	"github.com/magodo/tfpluginschema/schema"
+)
+
+// FormatVersion is the version of the JSON format that is supported.
+const FormatVersion = "1.0"
+
+type ProviderSchemas struct {
+	FormatVersion string               `json:"format_version"`
+	Schemas       map[string]*Provider `json:"provider_schemas,omitempty"`
+}
+
+type Provider struct {
+	Provider                 *Schema            `json:"provider,omitempty"`
+	ResourceSchemas          map[string]*Schema `json:"resource_schemas,omitempty"`
+	DataSourceSchemas        map[string]*Schema `json:"data_source_schemas,omitempty"`
+	EphemeralResourceSchemas map[string]*Schema `json:"ephemeral_resource_schemas,omitempty"`
+	// Referencing: github.com/hashicorp/terraform/internal/command/jsonfunction@v1.10.0
+	Functions map[string]*FunctionSignature `json:"functions,omitempty"`
+}
+
+type Schema struct {
+	Version int64  `json:"version"`
+	Block   *Block `json:"block,omitempty"`
+
+	Extension *SchemaExtension `json:"tfpluginschema,omitempty"`
+}
+
+type Block struct {
+	Attributes      map[string]*Attribute `json:"attributes,omitempty"`
+	BlockTypes      map[string]*BlockType `json:"block_types,omitempty"`
+	Description     string                `json:"description,omitempty"`
+	DescriptionKind string                `json:"description_kind,omitempty"`
+	Deprecated      bool                  `json:"deprecated,omitempty"`
+}
+
+type Attribute struct {
+	AttributeType       json.RawMessage `json:"type,omitempty"`
+	AttributeNestedType *NestedType     `json:"nested_type,omitempty"`
+	Description         string          `json:"description,omitempty"`
+	DescriptionKind     string          `json:"description_kind,omitempty"`
+	Deprecated          bool            `json:"deprecated,omitempty"`
+	Required            bool            `json:"required,omitempty"`
+	Optional            bool            `json:"optional,omitempty"`
+	Computed            bool            `json:"computed,omitempty"`
+	Sensitive           bool            `json:"sensitive,omitempty"`
+
+	Extension *AttributeExtension `json:"tfpluginschema,omitempty"`
+}
+
+type NestedType struct {
+	Attributes  map[string]*Attribute `json:"attributes,omitempty"`
+	NestingMode string                `json:"nesting_mode,omitempty"`
+}
+
+type BlockType struct {
+	NestingMode string `json:"nesting_mode,omitempty"`
+	Block       *Block `json:"block,omitempty"`
+	MinItems    int    `json:"min_items,omitempty"`
+	MaxItems    int    `json:"max_items,omitempty"`
+
+	Extension *BlockTypeExtension `json:"tfpluginschema,omitempty"`
+}
+
+type FunctionSignature struct {
+	Description        string               `json:"description,omitempty"`
+	Summary            string               `json:"summary,omitempty"`
+	DeprecationMessage string               `json:"deprecation_message,omitempty"`
+	ReturnType         json.RawMessage      `json:"return_type"`
+	Parameters         []*FunctionParameter `json:"parameters,omitempty"`
+	VariadicParameter  *FunctionParameter   `json:"variadic_parameter,omitempty"`
+
+	Extension *FunctionSignatureExtension `json:"tfpluginschema,omitempty"`
+}
+
+type FunctionParameter struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	IsNullable  bool            `json:"is_nullable,omitempty"`
+	Type        json.RawMessage `json:"type"`
+
+	Extension *FunctionParameterExtension `json:"tfpluginschema,omitempty"`
+}
+
+// The extensions hold the tfpluginschema only properties, which are not part of the Terraform core JSON format.
+// They are placed under the "tfpluginschema" key and are only written when explicitly asked for.
+
+type SchemaExtension struct {
+	DeprecationMessage string `json:"deprecation_message,omitempty"`
+}
+
+type AttributeExtension struct {
+	DeprecationMessage string              `json:"deprecation_message,omitempty"`
+	Default            json.RawMessage     `json:"default,omitempty"`
+	ForceNew           *bool               `json:"force_new,omitempty"`
+	ConflictsWith      []string            `json:"conflicts_with,omitempty"`
+	ExactlyOneOf       []string            `json:"exactly_one_of,omitempty"`
+	AtLeastOneOf       []string            `json:"at_least_one_of,omitempty"`
+	RequiredWith       []string            `json:"required_with,omitempty"`
+	Validators         []*schema.Validator `json:"validators,omitempty"`
+	RequiresReplaceIf  *bool               `json:"requires_replace_if,omitempty"`
+	PlanModifiers      []string            `json:"plan_modifiers,omitempty"`
+}
+
+type BlockTypeExtension struct {
+	DeprecationMessage string              `json:"deprecation_message,omitempty"`
+	Required           *bool               `json:"required,omitempty"`
+	Optional           *bool               `json:"optional,omitempty"`
+	Computed           *bool               `json:"computed,omitempty"`
+	ForceNew           *bool               `json:"force_new,omitempty"`
+	ConflictsWith      []string            `json:"conflicts_with,omitempty"`
+	ExactlyOneOf       []string            `json:"exactly_one_of,omitempty"`
+	AtLeastOneOf       []string            `json:"at_least_one_of,omitempty"`
+	RequiredWith       []string            `json:"required_with,omitempty"`
+	Validators         []*schema.Validator `json:"validators,omitempty"`
+	RequiresReplaceIf  *bool               `json:"requires_replace_if,omitempty"`
+	PlanModifiers      []string            `json:"plan_modifiers,omitempty"`
+}
+
+type FunctionSignatureExtension struct {
+	DescriptionKind string `json:"description_kind,omitempty"`
+}
+
+type FunctionParameterExtension struct {
+	DescriptionKind    string `json:"description_kind,omitempty"`
+	AllowUnknownValues bool   `json:"allow_unknown_values,omitempty"`
+}
+
+const (
+	descriptionKindPlain    = "plain"
+	descriptionKindMarkdown = "markdown"
+)
+
+const (
+	nestingModeSingle = "single"
+	nestingModeList   = "list"
+	nestingModeSet    = "set"
+	nestingModeMap    = "map"
+	nestingModeGroup  = "group"
+)
@@ -0,0 +1,295 @@
+// Package scaffold generates the skeleton HCL configuration of the resources, data sources and providers from their schemas.
+package scaffold
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// DefaultName is the default name label of the generated resource and data source blocks.
+const DefaultName = "example"
+
+type Options struct {
+	// Name is the name label of the resource or data source block. Defaults to DefaultName.
+	Name string
+	// RequiredOnly omits the optional arguments and blocks, instead of commenting them out.
+	RequiredOnly bool
+}
+
+// Resource generates the skeleton configuration of a resource block.
+//
+// The required arguments are filled with type-appropriate placeholders (e.g. "" for a string), or their Default if any.
+// The optional arguments are populated with their Default if any, otherwise they are commented out.
+// The computed-only attributes, and the computed "id" attribute of the root block, are skipped.
+// The nested blocks are generated by their MinItems (at least one for the required blocks), while the optional blocks
+// are commented out.
+// For each ExactlyOneOf (or AtLeastOneOf) group, only the first key in sorted order is regarded as required, while
+// the others are commented out.
+func Resource(resourceType string, sch *schema.Schema, opts *Options) ([]byte, error) {
+	return generate("resource", []string{resourceType, opts.name()}, sch, opts)
+}
+
+// DataSource is like Resource, but generates a data source block.
+func DataSource(dataSourceType string, sch *schema.Schema, opts *Options) ([]byte, error) {
+	return generate("data", []string{dataSourceType, opts.name()}, sch, opts)
+}
+
+// Provider is like Resource, but generates a provider configuration block. The Name option is not used.
+func Provider(providerName string, sch *schema.Schema, opts *Options) ([]byte, error) {
+	return generate("provider", []string{providerName}, sch, opts)
+}
+
+func (opts *Options) name() string {
+	if opts == nil || opts.Name == "" {
+		return DefaultName
+	}
+	return opts.Name
+}
+
+func generate(typeName string, labels []string, sch *schema.Schema, opts *Options) ([]byte, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	var blk *schema.SchemaBlock
+	if sch != nil {
+		blk = sch.Block
+	}
+
+	f := hclwrite.NewEmptyFile()
+	g := generator{requiredOnly: opts.RequiredOnly}
+	if err := g.block(f.Body().AppendNewBlock(typeName, labels).Body(), "", blk); err != nil {
+		return nil, err
+	}
+	return hclwrite.Format(f.Bytes()), nil
+}
+
+type generator struct {
+	// requiredOnly omits the optional arguments and blocks.
+	requiredOnly bool
+}
+
+// block generates the content of the block into the body. The prefix is the SDKv2 schema key prefix of the block.
+func (g generator) block(body *hclwrite.Body, prefix string, blk *schema.SchemaBlock) error {
+	if blk == nil {
+		return nil
+	}
+
+	for _, attr := range blk.Attributes {
+		if prefix == "" && attr.Name == "id" && attr.Computed {
+			continue
+		}
+		val, presence, err := g.attribute(prefix, attr)
+		if err != nil {
+			return err
+		}
+		switch presence {
+		case presenceSet:
+			body.SetAttributeValue(attr.Name, val)
+		case presenceCommented:
+			appendCommented(body, func(body *hclwrite.Body) error {
+				body.SetAttributeValue(attr.Name, val)
+				return nil
+			})
+		}
+	}
+
+	for _, nb := range blk.BlockTypes {
+		key := prefix + nb.TypeName
+		var labels []string
+		if nb.Nesting == schema.SchemaNestedBlockNestingModeMap {
+			labels = []string{"key"}
+		}
+
+		n := nb.MinItems
+		if n == 0 && (boolValue(nb.Required) || isChosen(key, nb.ExactlyOneOf, nb.AtLeastOneOf)) {
+			n = 1
+		}
+		switch nb.Nesting {
+		case schema.SchemaNestedBlockNestingModeSingle, schema.SchemaNestedBlockNestingModeGroup:
+			if n > 1 {
+				n = 1
+			}
+		}
+
+		if n == 0 {
+			if g.requiredOnly {
+				continue
+			}
+			// Only the required content is generated in the commented block, to avoid the nested comments.
+			cg := generator{requiredOnly: true}
+			if err := appendCommented(body, func(body *hclwrite.Body) error {
+				return cg.block(body.AppendNewBlock(nb.TypeName, labels).Body(), key+".0.", nb.Block)
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+		for i := 0; i < n; i++ {
+			if err := g.block(body.AppendNewBlock(nb.TypeName, labels).Body(), fmt.Sprintf("%s.%d.", key, i), nb.Block); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type presence int
+
+const (
+	presenceOmitted presence = iota
+	presenceCommented
+	presenceSet
+)
+
+// attribute returns the value of the attribute, and how it is present in the configuration.
+func (g generator) attribute(prefix string, attr *schema.SchemaAttribute) (cty.Value, presence, error) {
+	if !attr.Required && !attr.Optional {
+		return cty.NilVal, presenceOmitted, nil
+	}
+	key := prefix + attr.Name
+
+	dval, err := attr.DefaultValue()
+	if err != nil {
+		return cty.NilVal, presenceOmitted, fmt.Errorf("default of %q: %v", key, err)
+	}
+
+	// The keys of the ExactlyOneOf group that are not chosen can't be set, even if they have a Default.
+	if !attr.Required && len(attr.ExactlyOneOf) != 0 && !isChosen(key, attr.ExactlyOneOf) {
+		if g.requiredOnly {
+			return cty.NilVal, presenceOmitted, nil
+		}
+		if !dval.IsNull() {
+			return dval, presenceCommented, nil
+		}
+		val, err := g.placeholder(key, attr)
+		return val, presenceCommented, err
+	}
+
+	if !dval.IsNull() {
+		return dval, presenceSet, nil
+	}
+	if attr.Required || isChosen(key, attr.ExactlyOneOf, attr.AtLeastOneOf) {
+		val, err := g.placeholder(key, attr)
+		return val, presenceSet, err
+	}
+	if g.requiredOnly {
+		return cty.NilVal, presenceOmitted, nil
+	}
+	val, err := g.placeholder(key, attr)
+	return val, presenceCommented, err
+}
+
+// placeholder returns the placeholder value of the attribute.
+func (g generator) placeholder(key string, attr *schema.SchemaAttribute) (cty.Value, error) {
+	if attr.NestedType == nil {
+		if attr.Type == nil {
+			return cty.NilVal, fmt.Errorf("attribute %q has neither Type nor NestedType", key)
+		}
+		return placeholderValue(*attr.Type), nil
+	}
+
+	// Only the required nested attributes are generated, as the optional ones can't be commented out in an expression.
+	obj := map[string]cty.Value{}
+	og := generator{requiredOnly: true}
+	for _, nattr := range attr.NestedType.Attributes {
+		val, presence, err := og.attribute(key+".0.", nattr)
+		if err != nil {
+			return cty.NilVal, err
+		}
+		if presence == presenceSet {
+			obj[nattr.Name] = val
+		}
+	}
+	val := cty.ObjectVal(obj)
+	switch attr.NestedType.Nesting {
+	case schema.SchemaObjectNestingModeList:
+		return cty.ListVal([]cty.Value{val}), nil
+	case schema.SchemaObjectNestingModeSet:
+		return cty.SetVal([]cty.Value{val}), nil
+	case schema.SchemaObjectNestingModeMap:
+		return cty.MapVal(map[string]cty.Value{"key": val}), nil
+	default:
+		return val, nil
+	}
+}
+
+// placeholderValue returns the type-appropriate placeholder value of the type.
+func placeholderValue(ty cty.Type) cty.Value {
+	switch {
+	case ty == cty.String:
+		return cty.StringVal("")
+	case ty == cty.Number:
+		return cty.Zero
+	case ty == cty.Bool:
+		return cty.False
+	case ty.IsListType():
+		return cty.ListValEmpty(ty.ElementType())
+	case ty.IsSetType():
+		return cty.SetValEmpty(ty.ElementType())
+	case ty.IsMapType():
+		return cty.MapValEmpty(ty.ElementType())
+	case ty.IsObjectType():
+		vals := map[string]cty.Value{}
+		for name, aty := range ty.AttributeTypes() {
+			if ty.AttributeOptional(name) {
+				continue
+			}
+			vals[name] = placeholderValue(aty)
+		}
+		return cty.ObjectVal(vals)
+	case ty.IsTupleType():
+		var vals []cty.Value
+		for _, ety := range ty.TupleElementTypes() {
+			vals = append(vals, placeholderValue(ety))
+		}
+		if len(vals) == 0 {
+			return cty.EmptyTupleVal
+		}
+		return cty.TupleVal(vals)
+	default:
+		return cty.NullVal(ty)
+	}
+}
+
+// isChosen tells whether the key is the chosen one of any of the groups, which is the first key in sorted order.
+func isChosen(key string, groups ...[]string) bool {
+	for _, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+		keys := append([]string{}, group...)
+		sort.Strings(keys)
+		if keys[0] == key {
+			return true
+		}
+	}
+	return false
+}
+
+// appendCommented appends the content generated by the function to the body, as commented lines.
+func appendCommented(body *hclwrite.Body, f func(body *hclwrite.Body) error) error {
+	tmp := hclwrite.NewEmptyFile()
+	if err := f(tmp.Body()); err != nil {
+		return err
+	}
+	src := strings.TrimSuffix(string(hclwrite.Format(tmp.Bytes())), "\n")
+	var tokens hclwrite.Tokens
+	for _, line := range strings.Split(src, "\n") {
+		tokens = append(tokens, &hclwrite.Token{
+			Type:  hclsyntax.TokenComment,
+			Bytes: []byte(strings.TrimRight("# "+line, " ") + "\n"),
+		})
+	}
+	body.AppendUnstructuredTokens(tokens)
+	return nil
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
@@ -0,0 +1,210 @@
+package scaffold_test
+
+import (
+	"testing"
+
+	"github.com/magodo/tfpluginschema/scaffold"
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func ToPtr[T any](v T) *T {
+	return &v
+}
+
+var testSchema = &schema.Schema{
+	Block: &schema.SchemaBlock{
+		Attributes: []*schema.SchemaAttribute{
+			{Name: "id", Type: ToPtr(cty.String), Optional: true, Computed: true},
+			{Name: "name", Type: ToPtr(cty.String), Required: true},
+			{Name: "count", Type: ToPtr(cty.Number), Optional: true, Default: 2},
+			{Name: "size", Type: ToPtr(cty.Number), Optional: true, ExactlyOneOf: []string{"sku", "size"}},
+			{Name: "sku", Type: ToPtr(cty.String), Optional: true, Default: "basic", ExactlyOneOf: []string{"sku", "size"}},
+			{Name: "tags", Type: ToPtr(cty.Map(cty.String)), Optional: true},
+			{Name: "settings", Type: ToPtr(cty.Object(map[string]cty.Type{"a": cty.Bool, "b": cty.List(cty.Number)})), Required: true},
+			{Name: "arn", Type: ToPtr(cty.String), Computed: true},
+			{
+				Name:     "rules",
+				Required: true,
+				NestedType: &schema.SchemaObject{
+					Nesting: schema.SchemaObjectNestingModeList,
+					Attributes: []*schema.SchemaAttribute{
+						{Name: "priority", Type: ToPtr(cty.Number), Required: true},
+						{Name: "action", Type: ToPtr(cty.String), Optional: true, Default: "allow"},
+						{Name: "description", Type: ToPtr(cty.String), Optional: true},
+					},
+				},
+			},
+		},
+		BlockTypes: []*schema.SchemaNestedBlock{
+			{
+				TypeName: "network",
+				Nesting:  schema.SchemaNestedBlockNestingModeList,
+				MinItems: 2,
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{Name: "subnet_id", Type: ToPtr(cty.String), Required: true},
+						{Name: "zone", Type: ToPtr(cty.String), Optional: true},
+					},
+				},
+			},
+			{
+				TypeName: "identity",
+				Nesting:  schema.SchemaNestedBlockNestingModeSet,
+				Required: ToPtr(true),
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{Name: "type", Type: ToPtr(cty.String), Required: true},
+					},
+				},
+			},
+			{
+				TypeName: "timeouts",
+				Nesting:  schema.SchemaNestedBlockNestingModeSingle,
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{Name: "create", Type: ToPtr(cty.String), Optional: true},
+					},
+					BlockTypes: []*schema.SchemaNestedBlock{
+						{
+							TypeName: "retry",
+							Nesting:  schema.SchemaNestedBlockNestingModeMap,
+							MinItems: 1,
+							Block: &schema.SchemaBlock{
+								Attributes: []*schema.SchemaAttribute{
+									{Name: "times", Type: ToPtr(cty.Number), Required: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestResource(t *testing.T) {
+	cases := []struct {
+		name   string
+		opts   *scaffold.Options
+		expect string
+	}{
+		{
+			name: "default",
+			expect: `resource "foo_bar" "example" {
+  name  = ""
+  count = 2
+  size  = 0
+  # sku = "basic"
+  # tags = {}
+  settings = {
+    a = false
+    b = []
+  }
+  rules = [{
+    action   = "allow"
+    priority = 0
+  }]
+  network {
+    subnet_id = ""
+    # zone = ""
+  }
+  network {
+    subnet_id = ""
+    # zone = ""
+  }
+  identity {
+    type = ""
+  }
+  # timeouts {
+  #   retry "key" {
+  #     times = 0
+  #   }
+  # }
+}
+`,
+		},
+		{
+			name: "required only",
+			opts: &scaffold.Options{Name: "this", RequiredOnly: true},
+			expect: `resource "foo_bar" "this" {
+  name  = ""
+  count = 2
+  size  = 0
+  settings = {
+    a = false
+    b = []
+  }
+  rules = [{
+    action   = "allow"
+    priority = 0
+  }]
+  network {
+    subnet_id = ""
+  }
+  network {
+    subnet_id = ""
+  }
+  identity {
+    type = ""
+  }
+}
+`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := scaffold.Resource("foo_bar", testSchema, tt.opts)
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, string(b))
+		})
+	}
+}
+
+func TestDataSourceAndProvider(t *testing.T) {
+	sch := &schema.Schema{
+		Block: &schema.SchemaBlock{
+			Attributes: []*schema.SchemaAttribute{
+				{Name: "id", Type: ToPtr(cty.String), Computed: true},
+				{Name: "name", Type: ToPtr(cty.String), Required: true},
+			},
+		},
+	}
+
+	b, err := scaffold.DataSource("foo_bar", sch, nil)
+	require.NoError(t, err)
+	require.Equal(t, `data "foo_bar" "example" {
+  name = ""
+}
+`, string(b))
+
+	b, err = scaffold.Provider("foo", sch, &scaffold.Options{Name: "ignored"})
+	require.NoError(t, err)
+	require.Equal(t, `provider "foo" {
+  name = ""
+}
+`, string(b))
+}
+
+func TestResourceInvalidDefault(t *testing.T) {
+	sch := &schema.Schema{
+		Block: &schema.SchemaBlock{
+			BlockTypes: []*schema.SchemaNestedBlock{
+				{
+					TypeName: "network",
+					Nesting:  schema.SchemaNestedBlockNestingModeList,
+					MinItems: 1,
+					Block: &schema.SchemaBlock{
+						Attributes: []*schema.SchemaAttribute{
+							{Name: "mtu", Type: ToPtr(cty.Number), Optional: true, Default: "large"},
+						},
+					},
+				},
+			},
+		},
+	}
+	_, err := scaffold.Resource("foo_bar", sch, nil)
+	require.ErrorContains(t, err, `default of "network.0.mtu"`)
+}
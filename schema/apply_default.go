@@ -0,0 +1,166 @@
+package schema
+
+import (
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// ApplyDefaults returns a copy of the value of the block, with the null attributes that are not required set to
+// their Default, recursively into the nested blocks and the nested attribute objects.
+// The value is expected to conform to the implied type of the block. The unknown values are kept as is, and the marks
+// of the value are preserved. The returned error is a cty.PathError.
+func (b *SchemaBlock) ApplyDefaults(val cty.Value) (cty.Value, error) {
+	val, pvm := val.UnmarkDeepWithPaths()
+	out, err := b.applyDefaults(nil, val)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return out.MarkWithPaths(pvm), nil
+}
+
+func (b *SchemaBlock) applyDefaults(path cty.Path, val cty.Value) (cty.Value, error) {
+	if b == nil || !val.IsKnown() || val.IsNull() {
+		return val, nil
+	}
+	if !val.Type().IsObjectType() {
+		return cty.NilVal, path.NewErrorf("expect an object, got %s", val.Type().FriendlyName())
+	}
+
+	vals, err := applyAttributeDefaults(path, val, b.Attributes)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	for _, blk := range b.BlockTypes {
+		if !val.Type().HasAttribute(blk.TypeName) {
+			continue
+		}
+		bpath := path.Copy().GetAttr(blk.TypeName)
+		bval := val.GetAttr(blk.TypeName)
+		var err error
+		switch blk.Nesting {
+		case SchemaNestedBlockNestingModeSingle, SchemaNestedBlockNestingModeGroup:
+			bval, err = blk.Block.applyDefaults(bpath, bval)
+		default:
+			bval, err = transformElements(bpath, bval, blk.Block.applyDefaults)
+		}
+		if err != nil {
+			return cty.NilVal, err
+		}
+		vals[blk.TypeName] = bval
+	}
+
+	return cty.ObjectVal(vals), nil
+}
+
+// applyAttributeDefaults applies the defaults of the attributes to the known and non-null object value,
+// and returns all the attribute values of the object.
+func applyAttributeDefaults(path cty.Path, val cty.Value, attrs SchemaAttributes) (map[string]cty.Value, error) {
+	vals := val.AsValueMap()
+	if vals == nil {
+		vals = map[string]cty.Value{}
+	}
+	for _, attr := range attrs {
+		aval, ok := vals[attr.Name]
+		if !ok {
+			continue
+		}
+		apath := path.Copy().GetAttr(attr.Name)
+		if aval.IsNull() {
+			if attr.Required || attr.Default == nil {
+				continue
+			}
+			dval, err := attr.DefaultValue()
+			if err != nil {
+				return nil, apath.NewErrorf("default: %v", err)
+			}
+			if aval.Type() != cty.DynamicPseudoType {
+				dval, err = convert.Convert(dval, aval.Type())
+				if err != nil {
+					return nil, apath.NewErrorf("default: %v", err)
+				}
+			}
+			vals[attr.Name] = dval
+			continue
+		}
+
+		if attr.NestedType == nil || !aval.IsKnown() {
+			continue
+		}
+		var err error
+		if attr.NestedType.Nesting == SchemaObjectNestingModeSingle {
+			aval, err = attr.NestedType.applyDefaults(apath, aval)
+		} else {
+			aval, err = transformElements(apath, aval, attr.NestedType.applyDefaults)
+		}
+		if err != nil {
+			return nil, err
+		}
+		vals[attr.Name] = aval
+	}
+	return vals, nil
+}
+
+func (o *SchemaObject) applyDefaults(path cty.Path, val cty.Value) (cty.Value, error) {
+	if !val.IsKnown() || val.IsNull() {
+		return val, nil
+	}
+	if !val.Type().IsObjectType() {
+		return cty.NilVal, path.NewErrorf("expect an object, got %s", val.Type().FriendlyName())
+	}
+	vals, err := applyAttributeDefaults(path, val, o.Attributes)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return cty.ObjectVal(vals), nil
+}
+
+// transformElements calls the function on each element of the collection value, and returns the collection of the
+// results. Besides the list, set and map, the collection can also be a tuple or an object, which are used for the
+// collections of the elements that contain dynamic types.
+func transformElements(path cty.Path, val cty.Value, f func(cty.Path, cty.Value) (cty.Value, error)) (cty.Value, error) {
+	if !val.IsKnown() || val.IsNull() {
+		return val, nil
+	}
+	ty := val.Type()
+	if !ty.IsCollectionType() && !ty.IsTupleType() && !ty.IsObjectType() {
+		return cty.NilVal, path.NewErrorf("expect a collection, got %s", ty.FriendlyName())
+	}
+	if val.LengthInt() == 0 {
+		return val, nil
+	}
+
+	var (
+		l []cty.Value
+		m = map[string]cty.Value{}
+	)
+	for it := val.ElementIterator(); it.Next(); {
+		k, ev := it.Element()
+		epath := path.Copy().Index(k)
+		if ty.IsSetType() {
+			epath = path.Copy().Index(ev)
+		}
+		ev, err := f(epath, ev)
+		if err != nil {
+			return cty.NilVal, err
+		}
+		if k.Type() == cty.String {
+			m[k.AsString()] = ev
+		} else {
+			l = append(l, ev)
+		}
+	}
+
+	switch {
+	case ty.IsListType():
+		return cty.ListVal(l), nil
+	case ty.IsSetType():
+		return cty.SetVal(l), nil
+	case ty.IsTupleType():
+		return cty.TupleVal(l), nil
+	case ty.IsMapType():
+		return cty.MapVal(m), nil
+	default:
+		return cty.ObjectVal(m), nil
+	}
+}
@@ -0,0 +1,166 @@
+package schema_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestSchemaBlock_ApplyDefaults(t *testing.T) {
+	blk := &schema.SchemaBlock{
+		Attributes: []*schema.SchemaAttribute{
+			{Name: "name", Type: ToPtr(cty.String), Required: true},
+			{Name: "count", Type: ToPtr(cty.Number), Optional: true, Default: 1},
+			{Name: "ratio", Type: ToPtr(cty.Number), Optional: true, Computed: true, Default: big.NewFloat(0.5)},
+			{Name: "zones", Type: ToPtr(cty.List(cty.String)), Optional: true, Default: []interface{}{"1", "2"}},
+			{Name: "tags", Type: ToPtr(cty.Map(cty.String)), Optional: true, Default: map[string]interface{}{"env": "dev"}},
+			{Name: "enabled", Type: ToPtr(cty.Bool), Optional: true, Default: true},
+			{Name: "any", Type: ToPtr(cty.DynamicPseudoType), Optional: true, Default: "x"},
+			{
+				Name:     "rules",
+				Optional: true,
+				NestedType: &schema.SchemaObject{
+					Nesting: schema.SchemaObjectNestingModeList,
+					Attributes: []*schema.SchemaAttribute{
+						{Name: "priority", Type: ToPtr(cty.Number), Optional: true, Default: 100.0},
+					},
+				},
+			},
+		},
+		BlockTypes: []*schema.SchemaNestedBlock{
+			{
+				TypeName: "network",
+				Nesting:  schema.SchemaNestedBlockNestingModeSet,
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{Name: "mtu", Type: ToPtr(cty.Number), Optional: true, Default: 1500},
+					},
+				},
+			},
+			{
+				TypeName: "timeouts",
+				Nesting:  schema.SchemaNestedBlockNestingModeSingle,
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{Name: "create", Type: ToPtr(cty.String), Optional: true, Default: "30m"},
+					},
+				},
+			},
+		},
+	}
+
+	ruleType := cty.Object(map[string]cty.Type{"priority": cty.Number})
+	networkType := cty.Object(map[string]cty.Type{"mtu": cty.Number})
+	timeoutsType := cty.Object(map[string]cty.Type{"create": cty.String})
+
+	cases := []struct {
+		name   string
+		val    cty.Value
+		expect cty.Value
+		err    string
+	}{
+		{
+			name:   "null",
+			val:    cty.NullVal(cty.EmptyObject),
+			expect: cty.NullVal(cty.EmptyObject),
+		},
+		{
+			name: "defaults",
+			val: cty.ObjectVal(map[string]cty.Value{
+				"name":     cty.NullVal(cty.String),
+				"count":    cty.NullVal(cty.Number),
+				"ratio":    cty.NullVal(cty.Number),
+				"zones":    cty.NullVal(cty.List(cty.String)),
+				"tags":     cty.NullVal(cty.Map(cty.String)),
+				"enabled":  cty.False,
+				"any":      cty.NullVal(cty.DynamicPseudoType),
+				"rules":    cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"priority": cty.NullVal(cty.Number)})}),
+				"network":  cty.SetVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"mtu": cty.NullVal(cty.Number)})}),
+				"timeouts": cty.ObjectVal(map[string]cty.Value{"create": cty.NullVal(cty.String)}),
+			}),
+			expect: cty.ObjectVal(map[string]cty.Value{
+				"name":     cty.NullVal(cty.String),
+				"count":    cty.NumberIntVal(1),
+				"ratio":    cty.NumberFloatVal(0.5),
+				"zones":    cty.ListVal([]cty.Value{cty.StringVal("1"), cty.StringVal("2")}),
+				"tags":     cty.MapVal(map[string]cty.Value{"env": cty.StringVal("dev")}),
+				"enabled":  cty.False,
+				"any":      cty.StringVal("x"),
+				"rules":    cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"priority": cty.NumberIntVal(100)})}),
+				"network":  cty.SetVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"mtu": cty.NumberIntVal(1500)})}),
+				"timeouts": cty.ObjectVal(map[string]cty.Value{"create": cty.StringVal("30m")}),
+			}),
+		},
+		{
+			name: "unknown and empty",
+			val: cty.ObjectVal(map[string]cty.Value{
+				"name":     cty.StringVal("foo"),
+				"count":    cty.UnknownVal(cty.Number),
+				"ratio":    cty.NumberIntVal(2),
+				"zones":    cty.ListValEmpty(cty.String),
+				"tags":     cty.NullVal(cty.Map(cty.String)).Mark("sensitive"),
+				"enabled":  cty.NullVal(cty.Bool),
+				"any":      cty.NumberIntVal(1),
+				"rules":    cty.UnknownVal(cty.List(ruleType)),
+				"network":  cty.SetValEmpty(networkType),
+				"timeouts": cty.NullVal(timeoutsType),
+			}),
+			expect: cty.ObjectVal(map[string]cty.Value{
+				"name":     cty.StringVal("foo"),
+				"count":    cty.UnknownVal(cty.Number),
+				"ratio":    cty.NumberIntVal(2),
+				"zones":    cty.ListValEmpty(cty.String),
+				"tags":     cty.MapVal(map[string]cty.Value{"env": cty.StringVal("dev")}).Mark("sensitive"),
+				"enabled":  cty.True,
+				"any":      cty.NumberIntVal(1),
+				"rules":    cty.UnknownVal(cty.List(ruleType)),
+				"network":  cty.SetValEmpty(networkType),
+				"timeouts": cty.NullVal(timeoutsType),
+			}),
+		},
+		{
+			name:   "not an object",
+			val:    cty.StringVal("foo"),
+			expect: cty.NilVal,
+			err:    "expect an object, got string",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := blk.ApplyDefaults(tt.val)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, tt.expect.RawEquals(got), "expect:\n%#v\ngot:\n%#v", tt.expect, got)
+		})
+	}
+}
+
+func TestSchemaBlock_ApplyDefaultsInvalid(t *testing.T) {
+	blk := &schema.SchemaBlock{
+		BlockTypes: []*schema.SchemaNestedBlock{
+			{
+				TypeName: "network",
+				Nesting:  schema.SchemaNestedBlockNestingModeList,
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{Name: "mtu", Type: ToPtr(cty.Number), Optional: true, Default: "large"},
+					},
+				},
+			},
+		},
+	}
+	_, err := blk.ApplyDefaults(cty.ObjectVal(map[string]cty.Value{
+		"network": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"mtu": cty.NullVal(cty.Number)})}),
+	}))
+	var pathErr cty.PathError
+	require.ErrorAs(t, err, &pathErr)
+	require.Equal(t, cty.GetAttrPath("network").IndexInt(0).GetAttr("mtu"), pathErr.Path)
+	require.ErrorContains(t, err, "default: expect a number, got string")
+}
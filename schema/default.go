@@ -0,0 +1,363 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// DefaultValue returns the Default of the attribute as a cty value, which conforms to the attribute's type.
+// A null value is returned if there is no Default.
+//
+// It is the typed representation of the Default, which is kept as is across a JSON round-trip, while the Go type of
+// the raw Default might not be (e.g. an int is unmarshalled as an int64). The SDKv2 doesn't check the Go type of the
+// Default, so a Default that doesn't match the attribute's type (e.g. 1 for a string) is converted as cty does.
+func (attr *SchemaAttribute) DefaultValue() (cty.Value, error) {
+	ty, err := attr.ImpliedType()
+	if err != nil {
+		return cty.NilVal, err
+	}
+	val, err := goToCtyValue(attr.Default, ty)
+	if err == nil {
+		return val, nil
+	}
+	ity, ierr := impliedGoType(attr.Default)
+	if ierr != nil {
+		return cty.NilVal, err
+	}
+	ival, ierr := goToCtyValue(attr.Default, ity)
+	if ierr != nil {
+		return cty.NilVal, err
+	}
+	if val, ierr := convert.Convert(ival, ty); ierr == nil {
+		return val, nil
+	}
+	return cty.NilVal, err
+}
+
+// SetDefaultValue sets the Default of the attribute from a cty value.
+// Numbers are set as int64 if they are integers, as float64 if they can be represented exactly, otherwise as *big.Float.
+// Lists, sets and tuples are set as []interface{}, while maps and objects are set as map[string]interface{}.
+func (attr *SchemaAttribute) SetDefaultValue(val cty.Value) error {
+	v, err := ctyValueToGo(val)
+	if err != nil {
+		return err
+	}
+	attr.Default = v
+	return nil
+}
+
+// MarshalJSON marshals the attribute, with its Default encoded against the attribute's type.
+func (attr SchemaAttribute) MarshalJSON() ([]byte, error) {
+	type schemaAttribute SchemaAttribute
+	out := struct {
+		schemaAttribute
+		Default json.RawMessage `json:"default,omitempty"`
+	}{
+		schemaAttribute: schemaAttribute(attr),
+	}
+	if attr.Default != nil {
+		b, err := attr.marshalDefault()
+		if err != nil {
+			return nil, fmt.Errorf("marshalling default of attribute %q: %v", attr.Name, err)
+		}
+		out.Default = b
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON unmarshals the attribute, with its Default decoded against the attribute's type.
+func (attr *SchemaAttribute) UnmarshalJSON(b []byte) error {
+	type schemaAttribute SchemaAttribute
+	var in struct {
+		schemaAttribute
+		Default json.RawMessage `json:"default,omitempty"`
+	}
+	if err := json.Unmarshal(b, &in); err != nil {
+		return err
+	}
+	*attr = SchemaAttribute(in.schemaAttribute)
+	if len(in.Default) != 0 && string(in.Default) != "null" {
+		if err := attr.unmarshalDefault(in.Default); err != nil {
+			return fmt.Errorf("unmarshalling default of attribute %q: %v", attr.Name, err)
+		}
+	}
+	return nil
+}
+
+func (attr *SchemaAttribute) marshalDefault() ([]byte, error) {
+	ty, err := attr.ImpliedType()
+	if err != nil {
+		return nil, err
+	}
+	val, err := attr.DefaultValue()
+	if err != nil {
+		return nil, err
+	}
+	return ctyjson.Marshal(val, ty)
+}
+
+func (attr *SchemaAttribute) unmarshalDefault(b []byte) error {
+	ty, err := attr.ImpliedType()
+	if err != nil {
+		return err
+	}
+	val, err := ctyjson.Unmarshal(b, ty)
+	if err != nil {
+		return err
+	}
+	return attr.SetDefaultValue(val)
+}
+
+// goToCtyValue converts a Go value, as used by the Default of SDKv2 and FW, to a cty value of the given type.
+func goToCtyValue(v interface{}, ty cty.Type) (cty.Value, error) {
+	if v == nil {
+		return cty.NullVal(ty), nil
+	}
+	if val, ok := v.(cty.Value); ok {
+		return val, nil
+	}
+
+	if ty == cty.DynamicPseudoType {
+		ity, err := impliedGoType(v)
+		if err != nil {
+			return cty.NilVal, err
+		}
+		return goToCtyValue(v, ity)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch {
+	case ty == cty.String:
+		if rv.Kind() != reflect.String {
+			return cty.NilVal, fmt.Errorf("expect a string, got %T", v)
+		}
+		return cty.StringVal(rv.String()), nil
+	case ty == cty.Bool:
+		if rv.Kind() != reflect.Bool {
+			return cty.NilVal, fmt.Errorf("expect a bool, got %T", v)
+		}
+		return cty.BoolVal(rv.Bool()), nil
+	case ty == cty.Number:
+		return goToCtyNumber(v)
+	case ty.IsListType(), ty.IsSetType():
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return cty.NilVal, fmt.Errorf("expect a slice, got %T", v)
+		}
+		ety := ty.ElementType()
+		var vals []cty.Value
+		for i := 0; i < rv.Len(); i++ {
+			ev, err := goToCtyValue(rv.Index(i).Interface(), ety)
+			if err != nil {
+				return cty.NilVal, fmt.Errorf("[%d]: %v", i, err)
+			}
+			vals = append(vals, ev)
+		}
+		if ty.IsListType() {
+			if len(vals) == 0 {
+				return cty.ListValEmpty(ety), nil
+			}
+			return cty.ListVal(vals), nil
+		}
+		if len(vals) == 0 {
+			return cty.SetValEmpty(ety), nil
+		}
+		return cty.SetVal(vals), nil
+	case ty.IsTupleType():
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return cty.NilVal, fmt.Errorf("expect a slice, got %T", v)
+		}
+		etys := ty.TupleElementTypes()
+		if rv.Len() != len(etys) {
+			return cty.NilVal, fmt.Errorf("expect %d elements, got %d", len(etys), rv.Len())
+		}
+		var vals []cty.Value
+		for i, ety := range etys {
+			ev, err := goToCtyValue(rv.Index(i).Interface(), ety)
+			if err != nil {
+				return cty.NilVal, fmt.Errorf("[%d]: %v", i, err)
+			}
+			vals = append(vals, ev)
+		}
+		return cty.TupleVal(vals), nil
+	case ty.IsMapType():
+		if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+			return cty.NilVal, fmt.Errorf("expect a map keyed by string, got %T", v)
+		}
+		ety := ty.ElementType()
+		vals := map[string]cty.Value{}
+		iter := rv.MapRange()
+		for iter.Next() {
+			k := iter.Key().String()
+			ev, err := goToCtyValue(iter.Value().Interface(), ety)
+			if err != nil {
+				return cty.NilVal, fmt.Errorf("[%q]: %v", k, err)
+			}
+			vals[k] = ev
+		}
+		if len(vals) == 0 {
+			return cty.MapValEmpty(ety), nil
+		}
+		return cty.MapVal(vals), nil
+	case ty.IsObjectType():
+		if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+			return cty.NilVal, fmt.Errorf("expect a map keyed by string, got %T", v)
+		}
+		for _, k := range rv.MapKeys() {
+			if !ty.HasAttribute(k.String()) {
+				return cty.NilVal, fmt.Errorf("unexpected attribute %q", k.String())
+			}
+		}
+		vals := map[string]cty.Value{}
+		for name, aty := range ty.AttributeTypes() {
+			var av interface{}
+			if ev := rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key())); ev.IsValid() {
+				av = ev.Interface()
+			}
+			val, err := goToCtyValue(av, aty)
+			if err != nil {
+				return cty.NilVal, fmt.Errorf(".%s: %v", name, err)
+			}
+			vals[name] = val
+		}
+		return cty.ObjectVal(vals), nil
+	default:
+		return cty.NilVal, fmt.Errorf("unsupported type %s", ty.FriendlyName())
+	}
+}
+
+func goToCtyNumber(v interface{}) (cty.Value, error) {
+	switch v := v.(type) {
+	case *big.Float:
+		return cty.NumberVal(new(big.Float).Copy(v)), nil
+	case big.Float:
+		return cty.NumberVal(new(big.Float).Copy(&v)), nil
+	case *big.Int:
+		return cty.NumberVal(new(big.Float).SetInt(v)), nil
+	case json.Number:
+		return cty.ParseNumberVal(string(v))
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cty.NumberIntVal(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cty.NumberUIntVal(rv.Uint()), nil
+	case reflect.Float32:
+		// Format the float32 in its own precision, so that e.g. 0.1 is not converted to 0.10000000149011612.
+		return cty.ParseNumberVal(strconv.FormatFloat(rv.Float(), 'g', -1, 32))
+	case reflect.Float64:
+		return cty.NumberFloatVal(rv.Float()), nil
+	default:
+		return cty.NilVal, fmt.Errorf("expect a number, got %T", v)
+	}
+}
+
+// impliedGoType returns the cty type implied by a Go value, which is used for the dynamic typed attribute.
+func impliedGoType(v interface{}) (cty.Type, error) {
+	if val, ok := v.(cty.Value); ok {
+		return val.Type(), nil
+	}
+	switch v.(type) {
+	case *big.Float, big.Float, *big.Int, json.Number:
+		return cty.Number, nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return cty.String, nil
+	case reflect.Bool:
+		return cty.Bool, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return cty.Number, nil
+	case reflect.Slice, reflect.Array:
+		var etys []cty.Type
+		for i := 0; i < rv.Len(); i++ {
+			ety, err := impliedGoType(rv.Index(i).Interface())
+			if err != nil {
+				return cty.NilType, err
+			}
+			etys = append(etys, ety)
+		}
+		return cty.Tuple(etys), nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return cty.NilType, fmt.Errorf("unsupported map key type %s", rv.Type().Key())
+		}
+		atys := map[string]cty.Type{}
+		iter := rv.MapRange()
+		for iter.Next() {
+			aty, err := impliedGoType(iter.Value().Interface())
+			if err != nil {
+				return cty.NilType, err
+			}
+			atys[iter.Key().String()] = aty
+		}
+		return cty.Object(atys), nil
+	default:
+		return cty.NilType, fmt.Errorf("unsupported Go type %T", v)
+	}
+}
+
+// ctyValueToGo converts a cty value to a Go value.
+func ctyValueToGo(val cty.Value) (interface{}, error) {
+	if val.IsNull() {
+		return nil, nil
+	}
+	if !val.IsKnown() {
+		return nil, fmt.Errorf("unexpected unknown value")
+	}
+	val, _ = val.Unmark()
+
+	ty := val.Type()
+	switch {
+	case ty == cty.String:
+		return val.AsString(), nil
+	case ty == cty.Bool:
+		return val.True(), nil
+	case ty == cty.Number:
+		bf := val.AsBigFloat()
+		if bf.IsInt() {
+			if i, acc := bf.Int64(); acc == big.Exact {
+				return i, nil
+			}
+			return bf, nil
+		}
+		if f, _ := bf.Float64(); strconv.FormatFloat(f, 'f', -1, 64) == bf.Text('f', -1) {
+			return f, nil
+		}
+		return bf, nil
+	case ty.IsListType(), ty.IsSetType(), ty.IsTupleType():
+		l := []interface{}{}
+		for it := val.ElementIterator(); it.Next(); {
+			_, ev := it.Element()
+			v, err := ctyValueToGo(ev)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, v)
+		}
+		return l, nil
+	case ty.IsMapType(), ty.IsObjectType():
+		m := map[string]interface{}{}
+		for it := val.ElementIterator(); it.Next(); {
+			k, ev := it.Element()
+			v, err := ctyValueToGo(ev)
+			if err != nil {
+				return nil, err
+			}
+			m[k.AsString()] = v
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", ty.FriendlyName())
+	}
+}
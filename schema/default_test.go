@@ -0,0 +1,246 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var (
+	typeComparer = cmp.Comparer(cty.Type.Equals)
+	equateEmpty  = cmpopts.EquateEmpty()
+)
+
+func ToPtr[T any](v T) *T {
+	return &v
+}
+
+func TestSchemaAttribute_DefaultRoundTrip(t *testing.T) {
+	bigFloat, _, _ := big.ParseFloat("3.14159265358979323846264338327950288", 10, 512, big.ToNearestEven)
+
+	cases := []struct {
+		name   string
+		attr   schema.SchemaAttribute
+		expect cty.Value
+	}{
+		{
+			name:   "string",
+			attr:   schema.SchemaAttribute{Name: "a", Type: ToPtr(cty.String), Default: "foo"},
+			expect: cty.StringVal("foo"),
+		},
+		{
+			name:   "bool",
+			attr:   schema.SchemaAttribute{Name: "a", Type: ToPtr(cty.Bool), Default: true},
+			expect: cty.True,
+		},
+		{
+			name:   "int",
+			attr:   schema.SchemaAttribute{Name: "a", Type: ToPtr(cty.Number), Default: 123},
+			expect: cty.NumberIntVal(123),
+		},
+		{
+			name:   "float64",
+			attr:   schema.SchemaAttribute{Name: "a", Type: ToPtr(cty.Number), Default: 0.1},
+			expect: cty.NumberFloatVal(0.1),
+		},
+		{
+			name:   "float32",
+			attr:   schema.SchemaAttribute{Name: "a", Type: ToPtr(cty.Number), Default: float32(0.1)},
+			expect: cty.MustParseNumberVal("0.1"),
+		},
+		{
+			name:   "big float",
+			attr:   schema.SchemaAttribute{Name: "a", Type: ToPtr(cty.Number), Default: bigFloat},
+			expect: cty.NumberVal(bigFloat),
+		},
+		{
+			name:   "string from int",
+			attr:   schema.SchemaAttribute{Name: "a", Type: ToPtr(cty.String), Default: 1},
+			expect: cty.StringVal("1"),
+		},
+		{
+			name:   "bool from string",
+			attr:   schema.SchemaAttribute{Name: "a", Type: ToPtr(cty.Bool), Default: "true"},
+			expect: cty.True,
+		},
+		{
+			name:   "list",
+			attr:   schema.SchemaAttribute{Name: "a", Type: ToPtr(cty.List(cty.String)), Default: []interface{}{"a", "b"}},
+			expect: cty.ListVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}),
+		},
+		{
+			name:   "empty list",
+			attr:   schema.SchemaAttribute{Name: "a", Type: ToPtr(cty.List(cty.String)), Default: []interface{}{}},
+			expect: cty.ListValEmpty(cty.String),
+		},
+		{
+			name:   "set",
+			attr:   schema.SchemaAttribute{Name: "a", Type: ToPtr(cty.Set(cty.Number)), Default: []int{1, 2}},
+			expect: cty.SetVal([]cty.Value{cty.NumberIntVal(1), cty.NumberIntVal(2)}),
+		},
+		{
+			name:   "map",
+			attr:   schema.SchemaAttribute{Name: "a", Type: ToPtr(cty.Map(cty.Bool)), Default: map[string]bool{"x": true}},
+			expect: cty.MapVal(map[string]cty.Value{"x": cty.True}),
+		},
+		{
+			name: "object",
+			attr: schema.SchemaAttribute{
+				Name:    "a",
+				Type:    ToPtr(cty.Object(map[string]cty.Type{"s": cty.String, "n": cty.Number})),
+				Default: map[string]interface{}{"s": "foo"},
+			},
+			expect: cty.ObjectVal(map[string]cty.Value{"s": cty.StringVal("foo"), "n": cty.NullVal(cty.Number)}),
+		},
+		{
+			name: "tuple",
+			attr: schema.SchemaAttribute{
+				Name:    "a",
+				Type:    ToPtr(cty.Tuple([]cty.Type{cty.String, cty.Number})),
+				Default: []interface{}{"foo", 1},
+			},
+			expect: cty.TupleVal([]cty.Value{cty.StringVal("foo"), cty.NumberIntVal(1)}),
+		},
+		{
+			name:   "dynamic",
+			attr:   schema.SchemaAttribute{Name: "a", Type: ToPtr(cty.DynamicPseudoType), Default: map[string]interface{}{"x": []interface{}{"foo", 1.5}}},
+			expect: cty.ObjectVal(map[string]cty.Value{"x": cty.TupleVal([]cty.Value{cty.StringVal("foo"), cty.NumberFloatVal(1.5)})}),
+		},
+		{
+			name: "nested attribute",
+			attr: schema.SchemaAttribute{
+				Name: "a",
+				NestedType: &schema.SchemaObject{
+					Nesting: schema.SchemaObjectNestingModeList,
+					Attributes: []*schema.SchemaAttribute{
+						{Name: "s", Type: ToPtr(cty.String)},
+					},
+				},
+				Default: []interface{}{map[string]interface{}{"s": "foo"}},
+			},
+			expect: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"s": cty.StringVal("foo")})}),
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			val, err := tt.attr.DefaultValue()
+			require.NoError(t, err)
+			require.True(t, tt.expect.RawEquals(val), "expect %#v, got %#v", tt.expect, val)
+
+			b, err := json.Marshal(tt.attr)
+			require.NoError(t, err)
+
+			var got schema.SchemaAttribute
+			require.NoError(t, json.Unmarshal(b, &got))
+
+			val, err = got.DefaultValue()
+			require.NoError(t, err)
+			require.True(t, tt.expect.RawEquals(val), "expect %#v, got %#v", tt.expect, val)
+
+			// Marshalling the unmarshalled attribute again results into the same output.
+			b2, err := json.Marshal(got)
+			require.NoError(t, err)
+			require.JSONEq(t, string(b), string(b2))
+		})
+	}
+}
+
+func TestSchemaAttribute_DefaultInvalid(t *testing.T) {
+	attr := schema.SchemaAttribute{Name: "a", Type: ToPtr(cty.Map(cty.String)), Default: "foo"}
+	_, err := json.Marshal(attr)
+	require.Error(t, err)
+}
+
+func TestProviderSchema_JSONRoundTrip(t *testing.T) {
+	input := &schema.ProviderSchema{
+		ResourceSchemas: map[string]*schema.Schema{
+			"foo_resource": {
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{Name: "f", Type: ToPtr(cty.Number), Optional: true, Default: 0.1},
+						{Name: "i", Type: ToPtr(cty.Number), Optional: true, Default: int64(1)},
+						{Name: "l", Type: ToPtr(cty.List(cty.String)), Optional: true, Default: []interface{}{"a"}},
+						{Name: "m", Type: ToPtr(cty.Map(cty.Number)), Optional: true, Default: map[string]interface{}{"a": int64(1)}},
+					},
+				},
+			},
+		},
+	}
+
+	b, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	var output schema.ProviderSchema
+	require.NoError(t, json.Unmarshal(b, &output))
+
+	if !cmp.Equal(input, &output, equateEmpty, typeComparer) {
+		t.Error(cmp.Diff(input, &output, equateEmpty, typeComparer))
+	}
+}
+
+func TestProviderSchema_JSONRoundTripDefaultValue(t *testing.T) {
+	// The raw defaults in the Go types used by the SDKv2 and FW, which are not all kept after a JSON round-trip,
+	// while the typed DefaultValue is.
+	input := &schema.ProviderSchema{
+		ResourceSchemas: map[string]*schema.Schema{
+			"foo_resource": {
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{Name: "int", Type: ToPtr(cty.Number), Optional: true, Default: 1},
+						{Name: "int32", Type: ToPtr(cty.Number), Optional: true, Default: int32(2)},
+						{Name: "float32", Type: ToPtr(cty.Number), Optional: true, Default: float32(1.5)},
+						{Name: "float64_whole", Type: ToPtr(cty.Number), Optional: true, Default: float64(1)},
+						{Name: "big_float", Type: ToPtr(cty.Number), Optional: true, Default: big.NewFloat(3)},
+						{Name: "int_list", Type: ToPtr(cty.List(cty.Number)), Optional: true, Default: []int{1}},
+						// The SDKv2 doesn't check the Go type of the default.
+						{Name: "string_from_int", Type: ToPtr(cty.String), Optional: true, Default: 1},
+						{Name: "bool_from_string", Type: ToPtr(cty.Bool), Optional: true, Default: "true"},
+					},
+				},
+			},
+		},
+	}
+
+	b, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	var output schema.ProviderSchema
+	require.NoError(t, json.Unmarshal(b, &output))
+
+	ignoreDefault := cmpopts.IgnoreFields(schema.SchemaAttribute{}, "Default")
+	if !cmp.Equal(input, &output, equateEmpty, typeComparer, ignoreDefault) {
+		t.Error(cmp.Diff(input, &output, equateEmpty, typeComparer, ignoreDefault))
+	}
+
+	expect := map[string]cty.Value{
+		"int":              cty.NumberIntVal(1),
+		"int32":            cty.NumberIntVal(2),
+		"float32":          cty.NumberFloatVal(1.5),
+		"float64_whole":    cty.NumberIntVal(1),
+		"big_float":        cty.NumberIntVal(3),
+		"int_list":         cty.ListVal([]cty.Value{cty.NumberIntVal(1)}),
+		"string_from_int":  cty.StringVal("1"),
+		"bool_from_string": cty.True,
+	}
+	outAttrs := output.ResourceSchemas["foo_resource"].Block.Attributes
+	for i, attr := range input.ResourceSchemas["foo_resource"].Block.Attributes {
+		want := expect[attr.Name]
+		val, err := attr.DefaultValue()
+		require.NoError(t, err, attr.Name)
+		require.True(t, want.Equals(val).True(), "%s: expect %#v, got %#v", attr.Name, want, val)
+
+		val, err = outAttrs[i].DefaultValue()
+		require.NoError(t, err, attr.Name)
+		require.True(t, want.Equals(val).True(), "%s: expect %#v, got %#v after round-trip", attr.Name, want, val)
+	}
+
+	// The raw default is kept as is, rather than being normalized.
+	require.Equal(t, 1, input.ResourceSchemas["foo_resource"].Block.Attributes[0].Default)
+}
@@ -0,0 +1,33 @@
+package schema
+
+// The function definition is referencing the github.com/hashicorp/terraform-plugin-go/tfprotov6/function.go@v0.25.0
+
+import "github.com/zclconf/go-cty/cty"
+
+// Function is the definition of a provider-defined function.
+type Function struct {
+	Parameters []*FunctionParameter `json:"parameters,omitempty"`
+	// VariadicParameter is the optional final parameter, which accepts zero or more arguments.
+	VariadicParameter *FunctionParameter `json:"variadic_parameter,omitempty"`
+	ReturnType        *cty.Type          `json:"return_type,omitempty"`
+
+	Summary         string     `json:"summary,omitempty"`
+	Description     string     `json:"description,omitempty"`
+	DescriptionKind StringKind `json:"description_kind,omitempty"`
+
+	DeprecationMessage string `json:"deprecation_message,omitempty"`
+}
+
+type FunctionParameter struct {
+	Name string    `json:"name,omitempty"`
+	Type *cty.Type `json:"type,omitempty"`
+
+	// AllowNullValue indicates the argument can be null, otherwise Terraform raises an error before calling the function.
+	AllowNullValue bool `json:"allow_null_value,omitempty"`
+	// AllowUnknownValues indicates the argument can be unknown, otherwise Terraform skips calling the function and
+	// returns an unknown value.
+	AllowUnknownValues bool `json:"allow_unknown_values,omitempty"`
+
+	Description     string     `json:"description,omitempty"`
+	DescriptionKind StringKind `json:"description_kind,omitempty"`
+}
@@ -0,0 +1,154 @@
+package schema
+
+// A modified version based on: github.com/hashicorp/terraform/internal/configs/configschema/implied_type.go
+
+import (
+	"fmt"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ImpliedType returns the cty object type of the block, which is the type of the values that conform to the block.
+func (b *SchemaBlock) ImpliedType() (cty.Type, error) {
+	if b == nil {
+		return cty.EmptyObject, nil
+	}
+
+	atys := make(map[string]cty.Type)
+
+	for _, attr := range b.Attributes {
+		if _, exists := atys[attr.Name]; exists {
+			return cty.NilType, fmt.Errorf("invalid schema, duplicate attribute name: %s", attr.Name)
+		}
+		aty, err := attr.ImpliedType()
+		if err != nil {
+			return cty.NilType, err
+		}
+		atys[attr.Name] = aty
+	}
+
+	for _, blk := range b.BlockTypes {
+		if _, exists := atys[blk.TypeName]; exists {
+			return cty.NilType, fmt.Errorf("invalid schema, blocks and attributes cannot have the same name: %s", blk.TypeName)
+		}
+		bty, err := blk.ImpliedType()
+		if err != nil {
+			return cty.NilType, err
+		}
+		atys[blk.TypeName] = bty
+	}
+
+	return cty.Object(atys), nil
+}
+
+// ImpliedType returns the cty type of the nested block, taking the nesting mode into account.
+// A list or map of blocks that contain dynamic types is implied to be cty.DynamicPseudoType,
+// as the elements might have different types.
+func (blk *SchemaNestedBlock) ImpliedType() (cty.Type, error) {
+	ety, err := blk.Block.ImpliedType()
+	if err != nil {
+		return cty.NilType, fmt.Errorf("%s: %v", blk.TypeName, err)
+	}
+
+	switch blk.Nesting {
+	case SchemaNestedBlockNestingModeSingle, SchemaNestedBlockNestingModeGroup:
+		return ety, nil
+	case SchemaNestedBlockNestingModeList:
+		if ety.HasDynamicTypes() {
+			return cty.DynamicPseudoType, nil
+		}
+		return cty.List(ety), nil
+	case SchemaNestedBlockNestingModeSet:
+		if ety.HasDynamicTypes() {
+			return cty.NilType, fmt.Errorf("can't use cty.DynamicPseudoType inside a block type with NestingSet: %s", blk.TypeName)
+		}
+		return cty.Set(ety), nil
+	case SchemaNestedBlockNestingModeMap:
+		if ety.HasDynamicTypes() {
+			return cty.DynamicPseudoType, nil
+		}
+		return cty.Map(ety), nil
+	default:
+		return cty.NilType, fmt.Errorf("invalid nesting type %v: %s", blk.Nesting, blk.TypeName)
+	}
+}
+
+// ImpliedType returns the cty type of the attribute. For the nested attributes, the type is implied from the NestedType.
+func (attr *SchemaAttribute) ImpliedType() (cty.Type, error) {
+	return attr.impliedType(false)
+}
+
+// ConfigType is like ImpliedType, except that the attributes of the nested attribute objects that are not required are
+// marked as optional object attributes. It is the type to convert the configuration value of the attribute to.
+func (attr *SchemaAttribute) ConfigType() (cty.Type, error) {
+	return attr.impliedType(true)
+}
+
+func (attr *SchemaAttribute) impliedType(config bool) (cty.Type, error) {
+	if attr.NestedType != nil {
+		ty, err := attr.NestedType.impliedType(config)
+		if err != nil {
+			return cty.NilType, fmt.Errorf("%s: %v", attr.Name, err)
+		}
+		return ty, nil
+	}
+	if attr.Type == nil {
+		return cty.NilType, fmt.Errorf("attribute %q has neither Type nor NestedType", attr.Name)
+	}
+	return *attr.Type, nil
+}
+
+// ImpliedType returns the cty type of the nested attribute object, taking the nesting mode into account.
+// Same as the nested blocks, a list or map of objects that contain dynamic types is implied to be cty.DynamicPseudoType.
+func (o *SchemaObject) ImpliedType() (cty.Type, error) {
+	return o.impliedType(false)
+}
+
+// ConfigType is like ImpliedType, except that the attributes that are not required are marked as optional object attributes.
+func (o *SchemaObject) ConfigType() (cty.Type, error) {
+	return o.impliedType(true)
+}
+
+func (o *SchemaObject) impliedType(config bool) (cty.Type, error) {
+	atys := make(map[string]cty.Type)
+	var optionals []string
+	for _, attr := range o.Attributes {
+		if _, exists := atys[attr.Name]; exists {
+			return cty.NilType, fmt.Errorf("invalid schema, duplicate attribute name: %s", attr.Name)
+		}
+		aty, err := attr.impliedType(config)
+		if err != nil {
+			return cty.NilType, err
+		}
+		atys[attr.Name] = aty
+		if !attr.Required {
+			optionals = append(optionals, attr.Name)
+		}
+	}
+	ety := cty.Object(atys)
+	if config {
+		ety = cty.ObjectWithOptionalAttrs(atys, optionals)
+	}
+
+	switch o.Nesting {
+	case SchemaObjectNestingModeSingle:
+		return ety, nil
+	case SchemaObjectNestingModeList:
+		if ety.HasDynamicTypes() {
+			return cty.DynamicPseudoType, nil
+		}
+		return cty.List(ety), nil
+	case SchemaObjectNestingModeSet:
+		if ety.HasDynamicTypes() {
+			return cty.NilType, fmt.Errorf("can't use cty.DynamicPseudoType inside a nested attribute with NestingSet")
+		}
+		return cty.Set(ety), nil
+	case SchemaObjectNestingModeMap:
+		if ety.HasDynamicTypes() {
+			return cty.DynamicPseudoType, nil
+		}
+		return cty.Map(ety), nil
+	default:
+		return cty.NilType, fmt.Errorf("invalid nesting mode %v", o.Nesting)
+	}
+}
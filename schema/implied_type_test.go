@@ -0,0 +1,149 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestSchemaBlock_ImpliedType(t *testing.T) {
+	nestedObject := func(nesting schema.SchemaObjectNestingMode, ty cty.Type) *schema.SchemaObject {
+		return &schema.SchemaObject{
+			Nesting:    nesting,
+			Attributes: []*schema.SchemaAttribute{{Name: "x", Type: ToPtr(ty), Optional: true}},
+		}
+	}
+	nestedBlock := func(name string, nesting schema.SchemaNestedBlockNestingMode, ty cty.Type) *schema.SchemaNestedBlock {
+		return &schema.SchemaNestedBlock{
+			TypeName: name,
+			Nesting:  nesting,
+			Block: &schema.SchemaBlock{
+				Attributes: []*schema.SchemaAttribute{{Name: "x", Type: ToPtr(ty), Optional: true}},
+			},
+		}
+	}
+	objX := func(ty cty.Type) cty.Type {
+		return cty.Object(map[string]cty.Type{"x": ty})
+	}
+
+	cases := []struct {
+		name   string
+		block  *schema.SchemaBlock
+		expect cty.Type
+		err    string
+	}{
+		{
+			name:   "nil",
+			expect: cty.EmptyObject,
+		},
+		{
+			name: "nested attributes",
+			block: &schema.SchemaBlock{
+				Attributes: []*schema.SchemaAttribute{
+					{Name: "single", NestedType: nestedObject(schema.SchemaObjectNestingModeSingle, cty.String)},
+					{Name: "list", NestedType: nestedObject(schema.SchemaObjectNestingModeList, cty.String)},
+					{Name: "set", NestedType: nestedObject(schema.SchemaObjectNestingModeSet, cty.String)},
+					{Name: "map", NestedType: nestedObject(schema.SchemaObjectNestingModeMap, cty.String)},
+					{Name: "list_dynamic", NestedType: nestedObject(schema.SchemaObjectNestingModeList, cty.DynamicPseudoType)},
+					{Name: "map_dynamic", NestedType: nestedObject(schema.SchemaObjectNestingModeMap, cty.DynamicPseudoType)},
+					{Name: "single_dynamic", NestedType: nestedObject(schema.SchemaObjectNestingModeSingle, cty.DynamicPseudoType)},
+				},
+			},
+			expect: cty.Object(map[string]cty.Type{
+				"single":         objX(cty.String),
+				"list":           cty.List(objX(cty.String)),
+				"set":            cty.Set(objX(cty.String)),
+				"map":            cty.Map(objX(cty.String)),
+				"list_dynamic":   cty.DynamicPseudoType,
+				"map_dynamic":    cty.DynamicPseudoType,
+				"single_dynamic": objX(cty.DynamicPseudoType),
+			}),
+		},
+		{
+			name: "nested blocks",
+			block: &schema.SchemaBlock{
+				BlockTypes: []*schema.SchemaNestedBlock{
+					nestedBlock("single", schema.SchemaNestedBlockNestingModeSingle, cty.String),
+					nestedBlock("group", schema.SchemaNestedBlockNestingModeGroup, cty.String),
+					nestedBlock("list", schema.SchemaNestedBlockNestingModeList, cty.String),
+					nestedBlock("set", schema.SchemaNestedBlockNestingModeSet, cty.String),
+					nestedBlock("map", schema.SchemaNestedBlockNestingModeMap, cty.String),
+					nestedBlock("list_dynamic", schema.SchemaNestedBlockNestingModeList, cty.DynamicPseudoType),
+				},
+			},
+			expect: cty.Object(map[string]cty.Type{
+				"single":       objX(cty.String),
+				"group":        objX(cty.String),
+				"list":         cty.List(objX(cty.String)),
+				"set":          cty.Set(objX(cty.String)),
+				"map":          cty.Map(objX(cty.String)),
+				"list_dynamic": cty.DynamicPseudoType,
+			}),
+		},
+		{
+			name: "dynamic in set nested attribute",
+			block: &schema.SchemaBlock{
+				Attributes: []*schema.SchemaAttribute{
+					{Name: "set", NestedType: nestedObject(schema.SchemaObjectNestingModeSet, cty.DynamicPseudoType)},
+				},
+			},
+			err: "set: can't use cty.DynamicPseudoType inside a nested attribute with NestingSet",
+		},
+		{
+			name: "dynamic in set nested block",
+			block: &schema.SchemaBlock{
+				BlockTypes: []*schema.SchemaNestedBlock{
+					nestedBlock("set", schema.SchemaNestedBlockNestingModeSet, cty.DynamicPseudoType),
+				},
+			},
+			err: "can't use cty.DynamicPseudoType inside a block type with NestingSet: set",
+		},
+		{
+			name: "attribute and block name collision",
+			block: &schema.SchemaBlock{
+				Attributes: []*schema.SchemaAttribute{{Name: "foo", Type: ToPtr(cty.String)}},
+				BlockTypes: []*schema.SchemaNestedBlock{nestedBlock("foo", schema.SchemaNestedBlockNestingModeList, cty.String)},
+			},
+			err: "invalid schema, blocks and attributes cannot have the same name: foo",
+		},
+		{
+			name: "duplicate nested attribute name",
+			block: &schema.SchemaBlock{
+				Attributes: []*schema.SchemaAttribute{
+					{
+						Name: "obj",
+						NestedType: &schema.SchemaObject{
+							Nesting: schema.SchemaObjectNestingModeSingle,
+							Attributes: []*schema.SchemaAttribute{
+								{Name: "x", Type: ToPtr(cty.String)},
+								{Name: "x", Type: ToPtr(cty.Number)},
+							},
+						},
+					},
+				},
+			},
+			err: "obj: invalid schema, duplicate attribute name: x",
+		},
+		{
+			name: "attribute without type",
+			block: &schema.SchemaBlock{
+				Attributes: []*schema.SchemaAttribute{{Name: "foo"}},
+			},
+			err: `attribute "foo" has neither Type nor NestedType`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ty, err := tt.block.ImpliedType()
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, tt.expect.Equals(ty), "expect %#v, got %#v", tt.expect, ty)
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// PathNotFoundError is returned when a path can't be resolved in the schema block.
+type PathNotFoundError struct {
+	// Path is the path being looked up, in the dotted form.
+	Path string
+	// Resolved is the deepest prefix of the Path that is resolved, in the dotted form. It is empty if nothing is resolved.
+	Resolved string
+	// Segment is the first segment that can't be resolved.
+	Segment string
+}
+
+func (e *PathNotFoundError) Error() string {
+	if e.Resolved == "" {
+		return fmt.Sprintf("path %q not found: no %q at the root", e.Path, e.Segment)
+	}
+	return fmt.Sprintf("path %q not found: no %q under %q", e.Path, e.Segment, e.Resolved)
+}
+
+// lookupStep is a step of the path to look up.
+type lookupStep struct {
+	name string
+	// index indicates the step is known to be a list/set index or a map key.
+	// The steps from the dotted path are always names, whose meaning is decided by the schema.
+	index bool
+}
+
+// LookupPath looks up the attribute or the nested block by the dotted path (e.g. "network_interface.0.ip_configuration.subnet_id").
+// The list/set indices and map keys in the path are optional, and are skipped.
+// Either the attribute or the nested block is returned, or a *PathNotFoundError if the path can't be resolved.
+// Looking up into a non-nested attribute (e.g. a key of a map attribute) returns that attribute.
+func (b *SchemaBlock) LookupPath(path string) (*SchemaAttribute, *SchemaNestedBlock, error) {
+	var steps []lookupStep
+	if path != "" {
+		for _, seg := range strings.Split(path, ".") {
+			steps = append(steps, lookupStep{name: seg})
+		}
+	}
+	return b.lookup(steps)
+}
+
+// LookupCtyPath is like LookupPath, but accepts a cty.Path.
+func (b *SchemaBlock) LookupCtyPath(path cty.Path) (*SchemaAttribute, *SchemaNestedBlock, error) {
+	var steps []lookupStep
+	for _, step := range path {
+		switch step := step.(type) {
+		case cty.GetAttrStep:
+			steps = append(steps, lookupStep{name: step.Name})
+		case cty.IndexStep:
+			steps = append(steps, lookupStep{name: ctyKeyString(step.Key), index: true})
+		default:
+			return nil, nil, fmt.Errorf("unsupported path step %T", step)
+		}
+	}
+	return b.lookup(steps)
+}
+
+// LookupAttributePath is like LookupPath, but accepts a tftypes.AttributePath.
+func (b *SchemaBlock) LookupAttributePath(path *tftypes.AttributePath) (*SchemaAttribute, *SchemaNestedBlock, error) {
+	var steps []lookupStep
+	for _, step := range path.Steps() {
+		switch step := step.(type) {
+		case tftypes.AttributeName:
+			steps = append(steps, lookupStep{name: string(step)})
+		case tftypes.ElementKeyString:
+			steps = append(steps, lookupStep{name: string(step), index: true})
+		case tftypes.ElementKeyInt:
+			steps = append(steps, lookupStep{name: strconv.FormatInt(int64(step), 10), index: true})
+		case tftypes.ElementKeyValue:
+			steps = append(steps, lookupStep{name: tftypes.Value(step).String(), index: true})
+		default:
+			return nil, nil, fmt.Errorf("unsupported path step %T", step)
+		}
+	}
+	return b.lookup(steps)
+}
+
+func ctyKeyString(key cty.Value) string {
+	if !key.IsKnown() || key.IsNull() {
+		return key.GoString()
+	}
+	switch key.Type() {
+	case cty.String:
+		return key.AsString()
+	case cty.Number:
+		return key.AsBigFloat().Text('f', -1)
+	default:
+		return key.GoString()
+	}
+}
+
+func (b *SchemaBlock) lookup(steps []lookupStep) (*SchemaAttribute, *SchemaNestedBlock, error) {
+	if len(steps) == 0 {
+		return nil, nil, errors.New("empty path")
+	}
+
+	var (
+		attr *SchemaAttribute
+		blk  *SchemaNestedBlock
+
+		// The children of the current block or nested attribute object.
+		attrs  = b.Attributes
+		blocks = b.BlockTypes
+		// The collection kind of the current block or nested attribute object, that expects an optional index/key step.
+		collection collectionKind
+		// The type of the current non-nested attribute, or the element inside it.
+		valueType *cty.Type
+
+		resolved []string
+	)
+
+	notFound := func(seg string) error {
+		var path []string
+		for _, step := range steps {
+			path = append(path, step.name)
+		}
+		return &PathNotFoundError{
+			Path:     strings.Join(path, "."),
+			Resolved: strings.Join(resolved, "."),
+			Segment:  seg,
+		}
+	}
+
+	for _, step := range steps {
+		if valueType != nil {
+			ety, ok := elementType(*valueType, step)
+			if !ok {
+				return nil, nil, notFound(step.name)
+			}
+			valueType = &ety
+			resolved = append(resolved, step.name)
+			continue
+		}
+
+		if collection.isKey(step, attrs, blocks) {
+			collection = collectionNone
+			resolved = append(resolved, step.name)
+			continue
+		}
+		if step.index {
+			return nil, nil, notFound(step.name)
+		}
+
+		if a, ok := attrs.Map()[step.name]; ok {
+			attr, blk = a, nil
+			if a.NestedType != nil {
+				attrs, blocks = a.NestedType.Attributes, nil
+				collection = objectCollectionKind(a.NestedType.Nesting)
+			} else {
+				if a.Type == nil {
+					return nil, nil, notFound(step.name)
+				}
+				valueType = a.Type
+			}
+			resolved = append(resolved, step.name)
+			continue
+		}
+		if nb, ok := blocks.Map()[step.name]; ok {
+			attr, blk = nil, nb
+			attrs, blocks = nil, nil
+			if nb.Block != nil {
+				attrs, blocks = nb.Block.Attributes, nb.Block.BlockTypes
+			}
+			collection = blockCollectionKind(nb.Nesting)
+			resolved = append(resolved, step.name)
+			continue
+		}
+		return nil, nil, notFound(step.name)
+	}
+
+	return attr, blk, nil
+}
+
+type collectionKind int
+
+const (
+	collectionNone collectionKind = iota
+	collectionList
+	collectionMap
+)
+
+func blockCollectionKind(nesting SchemaNestedBlockNestingMode) collectionKind {
+	switch nesting {
+	case SchemaNestedBlockNestingModeList, SchemaNestedBlockNestingModeSet:
+		return collectionList
+	case SchemaNestedBlockNestingModeMap:
+		return collectionMap
+	default:
+		return collectionNone
+	}
+}
+
+func objectCollectionKind(nesting SchemaObjectNestingMode) collectionKind {
+	switch nesting {
+	case SchemaObjectNestingModeList, SchemaObjectNestingModeSet:
+		return collectionList
+	case SchemaObjectNestingModeMap:
+		return collectionMap
+	default:
+		return collectionNone
+	}
+}
+
+// isKey tells whether the step is the index (for list/set) or key (for map) of the collection.
+// For the ambiguous steps from the dotted path, an integer is regarded as an index, and a name that is not a child is
+// regarded as a map key.
+func (k collectionKind) isKey(step lookupStep, attrs SchemaAttributes, blocks SchemaNestedBlocks) bool {
+	switch k {
+	case collectionList:
+		return step.index || isIndex(step.name)
+	case collectionMap:
+		if step.index {
+			return true
+		}
+		_, isAttr := attrs.Map()[step.name]
+		_, isBlock := blocks.Map()[step.name]
+		return !isAttr && !isBlock
+	default:
+		return false
+	}
+}
+
+func isIndex(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 64)
+	return err == nil
+}
+
+// elementType returns the type of the element that the step refers to inside a value of the type.
+func elementType(ty cty.Type, step lookupStep) (cty.Type, bool) {
+	switch {
+	case ty == cty.DynamicPseudoType:
+		return cty.DynamicPseudoType, true
+	case ty.IsListType(), ty.IsSetType():
+		if !step.index && !isIndex(step.name) {
+			return cty.NilType, false
+		}
+		return ty.ElementType(), true
+	case ty.IsMapType():
+		return ty.ElementType(), true
+	case ty.IsObjectType():
+		if step.index || !ty.HasAttribute(step.name) {
+			return cty.NilType, false
+		}
+		return ty.AttributeType(step.name), true
+	case ty.IsTupleType():
+		i, err := strconv.Atoi(step.name)
+		if err != nil || i < 0 || i >= ty.Length() {
+			return cty.NilType, false
+		}
+		return ty.TupleElementType(i), true
+	default:
+		return cty.NilType, false
+	}
+}
@@ -0,0 +1,138 @@
+package schema_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var lookupBlock = &schema.SchemaBlock{
+	Attributes: []*schema.SchemaAttribute{
+		{Name: "name", Type: ToPtr(cty.String), Required: true},
+		{Name: "tags", Type: ToPtr(cty.Map(cty.String)), Optional: true},
+		{Name: "obj", Type: ToPtr(cty.Object(map[string]cty.Type{"x": cty.List(cty.Number)})), Optional: true},
+		{
+			Name: "nested",
+			NestedType: &schema.SchemaObject{
+				Nesting: schema.SchemaObjectNestingModeMap,
+				Attributes: []*schema.SchemaAttribute{
+					{Name: "value", Type: ToPtr(cty.String), Optional: true},
+				},
+			},
+			Optional: true,
+		},
+	},
+	BlockTypes: []*schema.SchemaNestedBlock{
+		{
+			TypeName: "network_interface",
+			Nesting:  schema.SchemaNestedBlockNestingModeList,
+			Block: &schema.SchemaBlock{
+				BlockTypes: []*schema.SchemaNestedBlock{
+					{
+						TypeName: "ip_configuration",
+						Nesting:  schema.SchemaNestedBlockNestingModeSingle,
+						Block: &schema.SchemaBlock{
+							Attributes: []*schema.SchemaAttribute{
+								{Name: "subnet_id", Type: ToPtr(cty.String), Required: true},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestSchemaBlock_LookupPath(t *testing.T) {
+	cases := []struct {
+		path      string
+		attribute string
+		block     string
+		notFound  *schema.PathNotFoundError
+	}{
+		{path: "name", attribute: "name"},
+		{path: "tags.foo", attribute: "tags"},
+		{path: "obj.x.0", attribute: "obj"},
+		{path: "nested", attribute: "nested"},
+		{path: "nested.key.value", attribute: "value"},
+		{path: "nested.value", attribute: "value"},
+		{path: "network_interface", block: "network_interface"},
+		{path: "network_interface.0.ip_configuration", block: "ip_configuration"},
+		{path: "network_interface.0.ip_configuration.subnet_id", attribute: "subnet_id"},
+		{path: "network_interface.ip_configuration.subnet_id", attribute: "subnet_id"},
+		{
+			path:     "foo",
+			notFound: &schema.PathNotFoundError{Path: "foo", Segment: "foo"},
+		},
+		{
+			path:     "network_interface.0.ip_configuration.0.subnet_id",
+			notFound: &schema.PathNotFoundError{Path: "network_interface.0.ip_configuration.0.subnet_id", Resolved: "network_interface.0.ip_configuration", Segment: "0"},
+		},
+		{
+			path:     "network_interface.0.0",
+			notFound: &schema.PathNotFoundError{Path: "network_interface.0.0", Resolved: "network_interface.0", Segment: "0"},
+		},
+		{
+			path:     "obj.y",
+			notFound: &schema.PathNotFoundError{Path: "obj.y", Resolved: "obj", Segment: "y"},
+		},
+		{
+			path:     "name.foo",
+			notFound: &schema.PathNotFoundError{Path: "name.foo", Resolved: "name", Segment: "foo"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.path, func(t *testing.T) {
+			attr, blk, err := lookupBlock.LookupPath(tt.path)
+			if tt.notFound != nil {
+				var nfErr *schema.PathNotFoundError
+				require.True(t, errors.As(err, &nfErr), "unexpected error: %v", err)
+				require.Equal(t, tt.notFound, nfErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.attribute != "" {
+				require.Nil(t, blk)
+				require.Equal(t, tt.attribute, attr.Name)
+			} else {
+				require.Nil(t, attr)
+				require.Equal(t, tt.block, blk.TypeName)
+			}
+		})
+	}
+
+	_, _, err := lookupBlock.LookupPath("")
+	require.Error(t, err)
+}
+
+func TestSchemaBlock_LookupCtyPath(t *testing.T) {
+	attr, _, err := lookupBlock.LookupCtyPath(cty.GetAttrPath("network_interface").IndexInt(0).GetAttr("ip_configuration").GetAttr("subnet_id"))
+	require.NoError(t, err)
+	require.Equal(t, "subnet_id", attr.Name)
+
+	// An index step is never a name.
+	attr, _, err = lookupBlock.LookupCtyPath(cty.GetAttrPath("nested").IndexString("value").GetAttr("value"))
+	require.NoError(t, err)
+	require.Equal(t, "value", attr.Name)
+
+	_, _, err = lookupBlock.LookupCtyPath(cty.IndexIntPath(0))
+	require.Equal(t, &schema.PathNotFoundError{Path: "0", Segment: "0"}, err)
+}
+
+func TestSchemaBlock_LookupAttributePath(t *testing.T) {
+	attr, _, err := lookupBlock.LookupAttributePath(tftypes.NewAttributePath().WithAttributeName("network_interface").WithElementKeyInt(0).WithAttributeName("ip_configuration").WithAttributeName("subnet_id"))
+	require.NoError(t, err)
+	require.Equal(t, "subnet_id", attr.Name)
+
+	attr, _, err = lookupBlock.LookupAttributePath(tftypes.NewAttributePath().WithAttributeName("tags").WithElementKeyString("foo"))
+	require.NoError(t, err)
+	require.Equal(t, "tags", attr.Name)
+
+	_, _, err = lookupBlock.LookupAttributePath(tftypes.NewAttributePath().WithAttributeName("network_interface").WithAttributeName("foo"))
+	require.Equal(t, &schema.PathNotFoundError{Path: "network_interface.foo", Resolved: "network_interface", Segment: "foo"}, err)
+}
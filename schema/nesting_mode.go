@@ -0,0 +1,135 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// The nesting mode names are following Terraform's naming, as is used in the `terraform providers schema -json` output.
+var nestedBlockNestingModeNames = map[SchemaNestedBlockNestingMode]string{
+	SchemaNestedBlockNestingModeInvalid: "invalid",
+	SchemaNestedBlockNestingModeSingle:  "single",
+	SchemaNestedBlockNestingModeList:    "list",
+	SchemaNestedBlockNestingModeSet:     "set",
+	SchemaNestedBlockNestingModeMap:     "map",
+	SchemaNestedBlockNestingModeGroup:   "group",
+}
+
+var objectNestingModeNames = map[SchemaObjectNestingMode]string{
+	SchemaObjectNestingModeInvalid: "invalid",
+	SchemaObjectNestingModeSingle:  "single",
+	SchemaObjectNestingModeList:    "list",
+	SchemaObjectNestingModeSet:     "set",
+	SchemaObjectNestingModeMap:     "map",
+}
+
+func (m SchemaNestedBlockNestingMode) String() string {
+	if name, ok := nestedBlockNestingModeNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("SchemaNestedBlockNestingMode(%d)", int(m))
+}
+
+func (m SchemaNestedBlockNestingMode) MarshalText() ([]byte, error) {
+	name, ok := nestedBlockNestingModeNames[m]
+	if !ok {
+		return nil, fmt.Errorf("unknown nesting mode %d", int(m))
+	}
+	return []byte(name), nil
+}
+
+func (m *SchemaNestedBlockNestingMode) UnmarshalText(b []byte) error {
+	for mode, name := range nestedBlockNestingModeNames {
+		if name == string(b) {
+			*m = mode
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown nesting mode %q", string(b))
+}
+
+// UnmarshalJSON accepts both the nesting mode name and the legacy integer form.
+func (m *SchemaNestedBlockNestingMode) UnmarshalJSON(b []byte) error {
+	if string(bytes.TrimSpace(b)) == "null" {
+		return nil
+	}
+	i, isInt, err := unmarshalLegacyInt(b)
+	if err != nil {
+		return err
+	}
+	if isInt {
+		if _, ok := nestedBlockNestingModeNames[SchemaNestedBlockNestingMode(i)]; !ok {
+			return fmt.Errorf("unknown nesting mode %d", i)
+		}
+		*m = SchemaNestedBlockNestingMode(i)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	return m.UnmarshalText([]byte(s))
+}
+
+func (m SchemaObjectNestingMode) String() string {
+	if name, ok := objectNestingModeNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("SchemaObjectNestingMode(%d)", int32(m))
+}
+
+func (m SchemaObjectNestingMode) MarshalText() ([]byte, error) {
+	name, ok := objectNestingModeNames[m]
+	if !ok {
+		return nil, fmt.Errorf("unknown nesting mode %d", int32(m))
+	}
+	return []byte(name), nil
+}
+
+func (m *SchemaObjectNestingMode) UnmarshalText(b []byte) error {
+	for mode, name := range objectNestingModeNames {
+		if name == string(b) {
+			*m = mode
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown nesting mode %q", string(b))
+}
+
+// UnmarshalJSON accepts both the nesting mode name and the legacy integer form.
+func (m *SchemaObjectNestingMode) UnmarshalJSON(b []byte) error {
+	if string(bytes.TrimSpace(b)) == "null" {
+		return nil
+	}
+	i, isInt, err := unmarshalLegacyInt(b)
+	if err != nil {
+		return err
+	}
+	if isInt {
+		if _, ok := objectNestingModeNames[SchemaObjectNestingMode(i)]; !ok {
+			return fmt.Errorf("unknown nesting mode %d", i)
+		}
+		*m = SchemaObjectNestingMode(i)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	return m.UnmarshalText([]byte(s))
+}
+
+// unmarshalLegacyInt parses the JSON as an integer, if it is not a JSON string.
+func unmarshalLegacyInt(b []byte) (int32, bool, error) {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || b[0] == '"' {
+		return 0, false, nil
+	}
+	i, err := strconv.ParseInt(string(b), 10, 32)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid nesting mode %s", string(b))
+	}
+	return int32(i), true, nil
+}
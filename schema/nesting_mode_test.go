@@ -0,0 +1,77 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestNestingMode_JSON(t *testing.T) {
+	input := &schema.SchemaBlock{
+		Attributes: []*schema.SchemaAttribute{
+			{
+				Name: "attr",
+				NestedType: &schema.SchemaObject{
+					Nesting: schema.SchemaObjectNestingModeSet,
+					Attributes: []*schema.SchemaAttribute{
+						{Name: "foo", Type: ToPtr(cty.String)},
+					},
+				},
+			},
+		},
+		BlockTypes: []*schema.SchemaNestedBlock{
+			{TypeName: "blk", Nesting: schema.SchemaNestedBlockNestingModeGroup, Block: &schema.SchemaBlock{}},
+		},
+	}
+
+	b, err := json.Marshal(input)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "attributes": [
+    {
+      "name": "attr",
+      "nested_type": {
+        "Attributes": [{"name": "foo", "type": "string"}],
+        "Nesting": "set"
+      }
+    }
+  ],
+  "block_types": [
+    {"type_name": "blk", "block": {}, "nesting_mode": "group"}
+  ]
+}`, string(b))
+
+	var output schema.SchemaBlock
+	require.NoError(t, json.Unmarshal(b, &output))
+	require.Equal(t, schema.SchemaObjectNestingModeSet, output.Attributes[0].NestedType.Nesting)
+	require.Equal(t, schema.SchemaNestedBlockNestingModeGroup, output.BlockTypes[0].Nesting)
+}
+
+func TestNestingMode_LegacyInteger(t *testing.T) {
+	var output schema.SchemaBlock
+	require.NoError(t, json.Unmarshal([]byte(`{
+  "attributes": [
+    {"name": "attr", "nested_type": {"Attributes": [{"name": "foo", "type": "string"}], "Nesting": 2}}
+  ],
+  "block_types": [
+    {"type_name": "blk", "block": {}, "nesting_mode": 4}
+  ]
+}`), &output))
+	require.Equal(t, schema.SchemaObjectNestingModeList, output.Attributes[0].NestedType.Nesting)
+	require.Equal(t, schema.SchemaNestedBlockNestingModeMap, output.BlockTypes[0].Nesting)
+}
+
+func TestNestingMode_Invalid(t *testing.T) {
+	var mode schema.SchemaNestedBlockNestingMode
+	require.Error(t, json.Unmarshal([]byte(`"tuple"`), &mode))
+	require.Error(t, json.Unmarshal([]byte(`9`), &mode))
+	require.Error(t, json.Unmarshal([]byte(`-1`), &mode))
+	var objMode schema.SchemaObjectNestingMode
+	require.Error(t, json.Unmarshal([]byte(`5`), &objMode))
+	require.Equal(t, "SchemaObjectNestingMode(9)", schema.SchemaObjectNestingMode(9).String())
+	_, err := json.Marshal(schema.SchemaObjectNestingMode(9))
+	require.Error(t, err)
+}
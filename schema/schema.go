@@ -1,19 +1,26 @@
 package schema
 
-// The schema definition is referencing the github.com/hashicorp/terraform-plugin-go/tfprotov6/schema.go@v0.23.0
+// The schema definition is referencing the github.com/hashicorp/terraform-plugin-go/tfprotov6/schema.go@v0.25.0
 // As tfprotov6 is compatible to the tfprotov5 (that SDKv2 is using).
 
 import "github.com/zclconf/go-cty/cty"
 
 type ProviderSchema struct {
-	Provider          *Schema            `json:"provider,omitempty"`
-	ResourceSchemas   map[string]*Schema `json:"resource_schemas,omitempty"`
-	DataSourceSchemas map[string]*Schema `json:"data_source_schemas,omitempty"`
+	Provider                 *Schema              `json:"provider,omitempty"`
+	ResourceSchemas          map[string]*Schema   `json:"resource_schemas,omitempty"`
+	DataSourceSchemas        map[string]*Schema   `json:"data_source_schemas,omitempty"`
+	EphemeralResourceSchemas map[string]*Schema   `json:"ephemeral_resource_schemas,omitempty"`
+	Functions                map[string]*Function `json:"functions,omitempty"`
 }
 
 type Schema struct {
 	Version int64        `json:"schema_version,omitempty"`
 	Block   *SchemaBlock `json:"block,omitempty"`
+
+	Description     string     `json:"description,omitempty"`
+	DescriptionKind StringKind `json:"description_kind,omitempty"`
+
+	DeprecationMessage string `json:"deprecation_message,omitempty"`
 }
 
 type SchemaBlock struct {
@@ -41,6 +48,17 @@ func (blocks SchemaNestedBlocks) Map() map[string]*SchemaNestedBlock {
 	return m
 }
 
+// DefaultDeprecationMessage is the deprecation message used for the deprecated attributes, blocks or schemas
+// that are converted from a source only telling whether it is deprecated, without a message (e.g. the protocol).
+const DefaultDeprecationMessage = "Deprecated"
+
+type StringKind int32
+
+const (
+	StringKindPlain    StringKind = 0
+	StringKindMarkdown StringKind = 1
+)
+
 type SchemaNestedBlockNestingMode int
 
 const (
@@ -60,16 +78,33 @@ type SchemaNestedBlock struct {
 	MinItems int `json:"min_items,omitempty"`
 	MaxItems int `json:"max_items,omitempty"`
 
+	Description     string     `json:"description,omitempty"`
+	DescriptionKind StringKind `json:"description_kind,omitempty"`
+
+	DeprecationMessage string `json:"deprecation_message,omitempty"`
+
 	// Extended properties
 	// SDKv2 Only
-	Required      *bool    `json:"required,omitempty"`
-	Optional      *bool    `json:"optional,omitempty"`
-	Computed      *bool    `json:"computed,omitempty"`
-	ForceNew      *bool    `json:"force_new,omitempty"`
+	Required *bool `json:"required,omitempty"`
+	Optional *bool `json:"optional,omitempty"`
+	Computed *bool `json:"computed,omitempty"`
+
+	// SDKv2 and FW (translated from the RequiresReplace plan modifiers)
+	ForceNew *bool `json:"force_new,omitempty"`
+
+	// SDKv2 and FW (translated from the path expression validators)
 	ConflictsWith []string `json:"conflicts_with,omitempty"`
 	ExactlyOneOf  []string `json:"exactly_one_of,omitempty"`
 	AtLeastOneOf  []string `json:"at_least_one_of,omitempty"`
 	RequiredWith  []string `json:"required_with,omitempty"`
+
+	// FW Only
+	Validators []*Validator `json:"validators,omitempty"`
+	// RequiresReplaceIf indicates the resource is replaced on change under some condition (e.g. RequiresReplaceIf, RequiresReplaceIfConfigured),
+	// while the unconditional replacement (RequiresReplace) is recorded as ForceNew.
+	RequiresReplaceIf *bool `json:"requires_replace_if,omitempty"`
+	// PlanModifiers are the descriptions of the plan modifiers, other than RequiresReplace.
+	PlanModifiers []string `json:"plan_modifiers,omitempty"`
 }
 
 type SchemaObject struct {
@@ -98,15 +133,29 @@ type SchemaAttribute struct {
 	Computed  bool `json:"computed,omitempty"`
 	Sensitive bool `json:"sensitive,omitempty"`
 
+	Description     string     `json:"description,omitempty"`
+	DescriptionKind StringKind `json:"description_kind,omitempty"`
+
+	DeprecationMessage string `json:"deprecation_message,omitempty"`
+
 	// Extended Properties
-	// SDKv2: Go types
-	// FW: attr.Value
+	// SDKv2 and FW: the raw Go value (e.g. int, []interface{}), use DefaultValue for the typed value that survives a JSON round-trip
 	Default interface{} `json:"default,omitempty"`
 
-	// SDKv2 Only
-	ForceNew      *bool    `json:"force_new,omitempty"`
+	// SDKv2 and FW (translated from the RequiresReplace plan modifiers)
+	ForceNew *bool `json:"force_new,omitempty"`
+
+	// SDKv2 and FW (translated from the path expression validators)
 	ConflictsWith []string `json:"conflicts_with,omitempty"`
 	ExactlyOneOf  []string `json:"exactly_one_of,omitempty"`
 	AtLeastOneOf  []string `json:"at_least_one_of,omitempty"`
 	RequiredWith  []string `json:"required_with,omitempty"`
+
+	// FW Only
+	Validators []*Validator `json:"validators,omitempty"`
+	// RequiresReplaceIf indicates the resource is replaced on change under some condition (e.g. RequiresReplaceIf, RequiresReplaceIfConfigured),
+	// while the unconditional replacement (RequiresReplace) is recorded as ForceNew.
+	RequiresReplaceIf *bool `json:"requires_replace_if,omitempty"`
+	// PlanModifiers are the descriptions of the plan modifiers, other than RequiresReplace.
+	PlanModifiers []string `json:"plan_modifiers,omitempty"`
 }
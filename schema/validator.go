@@ -0,0 +1,46 @@
+package schema
+
+// ValidatorKind is the kind of the constraint that a validator enforces.
+type ValidatorKind string
+
+const (
+	// ValidatorKindOpaque is a validator whose constraint is unknown, which is only described by its Description.
+	ValidatorKindOpaque ValidatorKind = "opaque"
+	// ValidatorKindOneOf requires the value to be one of the Values.
+	ValidatorKindOneOf ValidatorKind = "one_of"
+	// ValidatorKindNoneOf requires the value to be none of the Values.
+	ValidatorKindNoneOf ValidatorKind = "none_of"
+	// ValidatorKindLength requires the length of the string value to be in the range of Min and Max.
+	ValidatorKindLength ValidatorKind = "length"
+	// ValidatorKindRegexMatches requires the string value to match the Pattern.
+	ValidatorKindRegexMatches ValidatorKind = "regex_matches"
+	// ValidatorKindRange requires the number value to be in the range of Min and Max.
+	ValidatorKindRange ValidatorKind = "range"
+	// ValidatorKindSize requires the number of elements of the collection value to be in the range of Min and Max.
+	ValidatorKindSize ValidatorKind = "size"
+	// ValidatorKindConflictsWith requires none of the PathExpressions to be set, if the value is set.
+	ValidatorKindConflictsWith ValidatorKind = "conflicts_with"
+	// ValidatorKindExactlyOneOf requires exactly one of the value and the PathExpressions to be set.
+	ValidatorKindExactlyOneOf ValidatorKind = "exactly_one_of"
+	// ValidatorKindAtLeastOneOf requires at least one of the value and the PathExpressions to be set.
+	ValidatorKindAtLeastOneOf ValidatorKind = "at_least_one_of"
+	// ValidatorKindAlsoRequires requires all of the PathExpressions to be set, if the value is set.
+	ValidatorKindAlsoRequires ValidatorKind = "also_requires"
+)
+
+// Validator is the structured constraint of a FW validator. Only the fields related to the Kind are set.
+type Validator struct {
+	Kind ValidatorKind `json:"kind"`
+	// Description is the description of the validator, which is always set.
+	Description string `json:"description,omitempty"`
+
+	// Values are the string forms of the values (e.g. "1" for a number).
+	Values []string `json:"values,omitempty"`
+	// Min and Max are the inclusive bounds of the range, either of which can be absent.
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+	// Pattern is the regular expression.
+	Pattern string `json:"pattern,omitempty"`
+	// PathExpressions are the string forms of the FW path expressions (e.g. "<.foo" for a sibling attribute "foo").
+	PathExpressions []string `json:"path_expressions,omitempty"`
+}
@@ -0,0 +1,193 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+type NodeKind int
+
+const (
+	// NodeKindProviderSchema is the *ProviderSchema.
+	NodeKindProviderSchema NodeKind = iota + 1
+	// NodeKindProvider is the *Schema of the provider configuration.
+	NodeKindProvider
+	// NodeKindResource is the *Schema of a resource.
+	NodeKindResource
+	// NodeKindDataSource is the *Schema of a data source.
+	NodeKindDataSource
+	// NodeKindBlock is the *SchemaBlock, either the root block of a schema or the block of a nested block.
+	NodeKindBlock
+	// NodeKindNestedBlock is the *SchemaNestedBlock.
+	NodeKindNestedBlock
+	// NodeKindAttribute is the *SchemaAttribute.
+	NodeKindAttribute
+	// NodeKindObject is the *SchemaObject of a nested attribute.
+	NodeKindObject
+	// NodeKindEphemeralResource is the *Schema of an ephemeral resource.
+	NodeKindEphemeralResource
+)
+
+func (k NodeKind) String() string {
+	switch k {
+	case NodeKindProviderSchema:
+		return "provider schema"
+	case NodeKindProvider:
+		return "provider"
+	case NodeKindResource:
+		return "resource"
+	case NodeKindDataSource:
+		return "data source"
+	case NodeKindBlock:
+		return "block"
+	case NodeKindNestedBlock:
+		return "nested block"
+	case NodeKindAttribute:
+		return "attribute"
+	case NodeKindObject:
+		return "object"
+	case NodeKindEphemeralResource:
+		return "ephemeral resource"
+	default:
+		return fmt.Sprintf("NodeKind(%d)", int(k))
+	}
+}
+
+// Node is a node in the schema tree being walked.
+type Node struct {
+	Kind NodeKind
+	// Value is the schema element of the node, whose type is decided by the Kind.
+	Value interface{}
+	// Parent is the parent node, which is nil for the root node of the walk.
+	Parent *Node
+	// Name is the name of the node in its parent, which is the resource (or data source, ephemeral resource) type name,
+	// the attribute name or the nested block type name. It is empty for the other nodes.
+	Name string
+	// Path is the attribute path of the node from the root block of the enclosing schema, which consists of
+	// the attribute and nested block names. The nodes of a block or object share the path of its owner.
+	Path []string
+}
+
+// Visitor visits the nodes of the schema tree.
+// Returning SkipChildren skips the children of the current node, returning SkipAll stops the walk.
+// Returning any other error stops the walk, and the error is returned by Walk.
+type Visitor interface {
+	Visit(node *Node) error
+}
+
+// VisitorFunc is an adapter to allow the use of ordinary functions as the Visitor.
+type VisitorFunc func(node *Node) error
+
+func (f VisitorFunc) Visit(node *Node) error {
+	return f(node)
+}
+
+var (
+	// SkipChildren is used as a return value from the Visitor to skip the children of the current node.
+	SkipChildren = errors.New("skip children")
+	// SkipAll is used as a return value from the Visitor to stop the walk.
+	SkipAll = errors.New("skip all")
+)
+
+// Walk walks the schema tree rooted at the root in depth-first order, calling the visitor for each node,
+// including the root. The root can be any of *ProviderSchema, *Schema, *SchemaBlock, *SchemaNestedBlock,
+// *SchemaAttribute and *SchemaObject. A root *Schema is regarded as a resource schema.
+// The resources, data sources and ephemeral resources are walked in the order of their names, the attributes and blocks are walked
+// in the order they are defined, with the attributes walked before the nested blocks.
+func Walk(root interface{}, v Visitor) error {
+	var node *Node
+	switch root := root.(type) {
+	case *ProviderSchema:
+		node = &Node{Kind: NodeKindProviderSchema, Value: root}
+	case *Schema:
+		node = &Node{Kind: NodeKindResource, Value: root}
+	case *SchemaBlock:
+		node = &Node{Kind: NodeKindBlock, Value: root}
+	case *SchemaNestedBlock:
+		node = &Node{Kind: NodeKindNestedBlock, Value: root, Name: root.TypeName, Path: []string{root.TypeName}}
+	case *SchemaAttribute:
+		node = &Node{Kind: NodeKindAttribute, Value: root, Name: root.Name, Path: []string{root.Name}}
+	case *SchemaObject:
+		node = &Node{Kind: NodeKindObject, Value: root}
+	default:
+		return fmt.Errorf("unsupported root type %T", root)
+	}
+	if err := walk(node, v); err != nil && err != SkipAll {
+		return err
+	}
+	return nil
+}
+
+func walk(node *Node, v Visitor) error {
+	if err := v.Visit(node); err != nil {
+		if err == SkipChildren {
+			return nil
+		}
+		return err
+	}
+	for _, child := range children(node) {
+		if err := walk(child, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func children(node *Node) []*Node {
+	var out []*Node
+	switch value := node.Value.(type) {
+	case *ProviderSchema:
+		if value.Provider != nil {
+			out = append(out, &Node{Kind: NodeKindProvider, Value: value.Provider, Parent: node})
+		}
+		for _, name := range sortedSchemaNames(value.ResourceSchemas) {
+			out = append(out, &Node{Kind: NodeKindResource, Value: value.ResourceSchemas[name], Parent: node, Name: name})
+		}
+		for _, name := range sortedSchemaNames(value.DataSourceSchemas) {
+			out = append(out, &Node{Kind: NodeKindDataSource, Value: value.DataSourceSchemas[name], Parent: node, Name: name})
+		}
+		for _, name := range sortedSchemaNames(value.EphemeralResourceSchemas) {
+			out = append(out, &Node{Kind: NodeKindEphemeralResource, Value: value.EphemeralResourceSchemas[name], Parent: node, Name: name})
+		}
+	case *Schema:
+		if value.Block != nil {
+			out = append(out, &Node{Kind: NodeKindBlock, Value: value.Block, Parent: node})
+		}
+	case *SchemaBlock:
+		for _, attr := range value.Attributes {
+			out = append(out, &Node{Kind: NodeKindAttribute, Value: attr, Parent: node, Name: attr.Name, Path: appendPath(node.Path, attr.Name)})
+		}
+		for _, blk := range value.BlockTypes {
+			out = append(out, &Node{Kind: NodeKindNestedBlock, Value: blk, Parent: node, Name: blk.TypeName, Path: appendPath(node.Path, blk.TypeName)})
+		}
+	case *SchemaNestedBlock:
+		if value.Block != nil {
+			out = append(out, &Node{Kind: NodeKindBlock, Value: value.Block, Parent: node, Path: node.Path})
+		}
+	case *SchemaAttribute:
+		if value.NestedType != nil {
+			out = append(out, &Node{Kind: NodeKindObject, Value: value.NestedType, Parent: node, Path: node.Path})
+		}
+	case *SchemaObject:
+		for _, attr := range value.Attributes {
+			out = append(out, &Node{Kind: NodeKindAttribute, Value: attr, Parent: node, Name: attr.Name, Path: appendPath(node.Path, attr.Name)})
+		}
+	}
+	return out
+}
+
+func appendPath(path []string, name string) []string {
+	out := make([]string, len(path), len(path)+1)
+	copy(out, path)
+	return append(out, name)
+}
+
+func sortedSchemaNames(m map[string]*Schema) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
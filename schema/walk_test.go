@@ -0,0 +1,122 @@
+package schema_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var walkProviderSchema = &schema.ProviderSchema{
+	Provider: &schema.Schema{Block: &schema.SchemaBlock{
+		Attributes: []*schema.SchemaAttribute{{Name: "endpoint", Type: ToPtr(cty.String), Optional: true}},
+	}},
+	ResourceSchemas: map[string]*schema.Schema{
+		"foo_b": {Block: &schema.SchemaBlock{}},
+		"foo_a": {Block: lookupBlock},
+	},
+	DataSourceSchemas: map[string]*schema.Schema{
+		"foo_a": {Block: &schema.SchemaBlock{
+			Attributes: []*schema.SchemaAttribute{{Name: "id", Type: ToPtr(cty.String), Required: true}},
+		}},
+	},
+}
+
+// walkRecorder records each visited node in form of "<kind> <name> <path>".
+func walkRecorder(out *[]string, action func(node *schema.Node) error) schema.Visitor {
+	return schema.VisitorFunc(func(node *schema.Node) error {
+		*out = append(*out, strings.TrimSpace(node.Kind.String()+" "+node.Name+" "+strings.Join(node.Path, ".")))
+		if action != nil {
+			return action(node)
+		}
+		return nil
+	})
+}
+
+func TestWalk(t *testing.T) {
+	var got []string
+	require.NoError(t, schema.Walk(walkProviderSchema, walkRecorder(&got, nil)))
+	require.Equal(t, []string{
+		"provider schema",
+		"provider",
+		"block",
+		"attribute endpoint endpoint",
+		"resource foo_a",
+		"block",
+		"attribute name name",
+		"attribute tags tags",
+		"attribute obj obj",
+		"attribute nested nested",
+		"object  nested",
+		"attribute value nested.value",
+		"nested block network_interface network_interface",
+		"block  network_interface",
+		"nested block ip_configuration network_interface.ip_configuration",
+		"block  network_interface.ip_configuration",
+		"attribute subnet_id network_interface.ip_configuration.subnet_id",
+		"resource foo_b",
+		"block",
+		"data source foo_a",
+		"block",
+		"attribute id id",
+	}, got)
+}
+
+func TestWalk_Parent(t *testing.T) {
+	var parents []string
+	require.NoError(t, schema.Walk(lookupBlock, schema.VisitorFunc(func(node *schema.Node) error {
+		if node.Name == "subnet_id" {
+			for p := node.Parent; p != nil; p = p.Parent {
+				parents = append(parents, p.Kind.String())
+			}
+			require.Equal(t, "ip_configuration", node.Parent.Parent.Value.(*schema.SchemaNestedBlock).TypeName)
+		}
+		return nil
+	})))
+	require.Equal(t, []string{"block", "nested block", "block", "nested block", "block"}, parents)
+}
+
+func TestWalk_Skip(t *testing.T) {
+	var got []string
+	require.NoError(t, schema.Walk(walkProviderSchema, walkRecorder(&got, func(node *schema.Node) error {
+		switch node.Kind {
+		case schema.NodeKindProvider, schema.NodeKindAttribute, schema.NodeKindNestedBlock:
+			return schema.SkipChildren
+		case schema.NodeKindResource:
+			if node.Name == "foo_b" {
+				return schema.SkipAll
+			}
+		}
+		return nil
+	})))
+	require.Equal(t, []string{
+		"provider schema",
+		"provider",
+		"resource foo_a",
+		"block",
+		"attribute name name",
+		"attribute tags tags",
+		"attribute obj obj",
+		"attribute nested nested",
+		"nested block network_interface network_interface",
+		"resource foo_b",
+	}, got)
+}
+
+func TestWalk_Error(t *testing.T) {
+	errStop := errors.New("stop")
+	var got []string
+	err := schema.Walk(walkProviderSchema.Provider, walkRecorder(&got, func(node *schema.Node) error {
+		if node.Kind == schema.NodeKindAttribute {
+			return errStop
+		}
+		return nil
+	}))
+	require.Equal(t, errStop, err)
+	require.Equal(t, []string{"resource", "block", "attribute endpoint endpoint"}, got)
+
+	require.Error(t, schema.Walk("foo", schema.VisitorFunc(func(*schema.Node) error { return nil })))
+}
@@ -0,0 +1,350 @@
+// Package structgen generates the Go struct types of the resources, data sources and providers from their schemas.
+package structgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type Options struct {
+	// PackageName is the package name of the generated file. Defaults to "main".
+	PackageName string
+	// TypeName is the name of the root struct type, which is required.
+	TypeName string
+}
+
+// Generate generates a gofmt'd Go file that contains the struct types of the schema.
+//
+// The root struct type is named by the TypeName option, while the nested blocks, nested attribute objects and object
+// typed attributes become sub-structs named by concatenating the name of the parent struct and the field name
+// (e.g. VirtualMachineNetworkInterface). Each field is tagged with the `tfsdk`, `cty` and `json` tags.
+//
+// The lists and sets are mapped to slices, the maps are mapped to maps, the numbers are mapped to float64, and the
+// dynamic typed values (and the tuples' elements) are mapped to interface{}.
+// The attributes that are not required (and the single nested blocks that are not required) are mapped to pointers,
+// except the slices and maps that are already nilable.
+func Generate(sch *schema.Schema, opts Options) ([]byte, error) {
+	if opts.TypeName == "" {
+		return nil, fmt.Errorf("type name is required")
+	}
+	if opts.PackageName == "" {
+		opts.PackageName = "main"
+	}
+	var blk *schema.SchemaBlock
+	if sch != nil {
+		blk = sch.Block
+	}
+
+	g := &generator{typeNames: map[string]bool{}}
+	name := g.newTypeName(opts.TypeName)
+	def := &structDef{name: name}
+	if sch != nil && sch.Description != "" {
+		def.doc = sch.Description
+	}
+	g.structs = append(g.structs, def)
+	if err := g.block(def, blk); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by tfpluginschema. DO NOT EDIT.\n\npackage %s\n", opts.PackageName)
+	for _, def := range g.structs {
+		buf.WriteString("\n")
+		def.write(&buf)
+	}
+	b, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting the generated code: %v", err)
+	}
+	return b, nil
+}
+
+type generator struct {
+	// structs are the struct types in the order of definition, with the root struct being the first.
+	structs   []*structDef
+	typeNames map[string]bool
+}
+
+type structDef struct {
+	name   string
+	doc    string
+	fields []field
+}
+
+type field struct {
+	name    string
+	typ     string
+	tagName string
+	// omitEmpty indicates the `json` tag has the "omitempty" option.
+	omitEmpty bool
+	doc       string
+}
+
+func (def *structDef) write(buf *bytes.Buffer) {
+	if def.doc != "" {
+		writeDoc(buf, def.name+" "+lowerFirst(def.doc), "")
+	}
+	fmt.Fprintf(buf, "type %s struct {\n", def.name)
+	for _, f := range def.fields {
+		if f.doc != "" {
+			writeDoc(buf, f.doc, "\t")
+		}
+		jsonTag := f.tagName
+		if f.omitEmpty {
+			jsonTag += ",omitempty"
+		}
+		fmt.Fprintf(buf, "\t%s %s `tfsdk:%q cty:%q json:%q`\n", f.name, f.typ, f.tagName, f.tagName, jsonTag)
+	}
+	buf.WriteString("}\n")
+}
+
+func writeDoc(buf *bytes.Buffer, doc, indent string) {
+	for _, line := range strings.Split(strings.TrimSpace(doc), "\n") {
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+		if line == "" {
+			fmt.Fprintf(buf, "%s//\n", indent)
+			continue
+		}
+		fmt.Fprintf(buf, "%s// %s\n", indent, line)
+	}
+}
+
+// newStruct defines a new struct type, whose name is decided by the parent struct and the field.
+func (g *generator) newStruct(parent *structDef, fieldName string) *structDef {
+	def := &structDef{name: g.newTypeName(parent.name + fieldName)}
+	g.structs = append(g.structs, def)
+	return def
+}
+
+// newTypeName returns a unique type name based on the name.
+func (g *generator) newTypeName(name string) string {
+	out := name
+	for i := 2; g.typeNames[out]; i++ {
+		out = fmt.Sprintf("%s%d", name, i)
+	}
+	g.typeNames[out] = true
+	return out
+}
+
+func (g *generator) addField(def *structDef, f field) error {
+	for _, of := range def.fields {
+		if of.name == f.name {
+			return fmt.Errorf("%s: %q and %q are both mapped to the field %s", def.name, of.tagName, f.tagName, f.name)
+		}
+	}
+	def.fields = append(def.fields, f)
+	return nil
+}
+
+func (g *generator) block(def *structDef, blk *schema.SchemaBlock) error {
+	if blk == nil {
+		return nil
+	}
+	if err := g.attributes(def, blk.Attributes); err != nil {
+		return err
+	}
+	for _, nb := range blk.BlockTypes {
+		name := goName(nb.TypeName)
+		sub := g.newStruct(def, name)
+		if err := g.block(sub, nb.Block); err != nil {
+			return err
+		}
+
+		f := field{name: name, tagName: nb.TypeName, doc: description(nb.Description, nb.DeprecationMessage)}
+		switch nb.Nesting {
+		case schema.SchemaNestedBlockNestingModeSingle:
+			f.typ = sub.name
+			if !boolValue(nb.Required) && nb.MinItems == 0 {
+				f.typ = "*" + sub.name
+				f.omitEmpty = true
+			}
+		case schema.SchemaNestedBlockNestingModeGroup:
+			f.typ = sub.name
+		case schema.SchemaNestedBlockNestingModeList, schema.SchemaNestedBlockNestingModeSet:
+			f.typ = "[]" + sub.name
+			f.omitEmpty = true
+		case schema.SchemaNestedBlockNestingModeMap:
+			f.typ = "map[string]" + sub.name
+			f.omitEmpty = true
+		default:
+			return fmt.Errorf("%s.%s: invalid nesting mode %v", def.name, name, nb.Nesting)
+		}
+		if err := g.addField(def, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *generator) attributes(def *structDef, attrs schema.SchemaAttributes) error {
+	for _, attr := range attrs {
+		name := goName(attr.Name)
+
+		var typ string
+		switch {
+		case attr.NestedType != nil:
+			sub := g.newStruct(def, name)
+			if err := g.attributes(sub, attr.NestedType.Attributes); err != nil {
+				return err
+			}
+			switch attr.NestedType.Nesting {
+			case schema.SchemaObjectNestingModeSingle:
+				typ = sub.name
+			case schema.SchemaObjectNestingModeList, schema.SchemaObjectNestingModeSet:
+				typ = "[]" + sub.name
+			case schema.SchemaObjectNestingModeMap:
+				typ = "map[string]" + sub.name
+			default:
+				return fmt.Errorf("%s.%s: invalid nesting mode %v", def.name, name, attr.NestedType.Nesting)
+			}
+		case attr.Type != nil:
+			var err error
+			typ, err = g.goType(def, name, *attr.Type)
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%s.%s: attribute %q has neither Type nor NestedType", def.name, name, attr.Name)
+		}
+
+		f := field{name: name, typ: typ, tagName: attr.Name, doc: description(attr.Description, attr.DeprecationMessage)}
+		if !attr.Required {
+			f.typ = optionalType(typ)
+			f.omitEmpty = true
+		}
+		if err := g.addField(def, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// goType returns the Go type of the cty type. The object types become sub-structs of the parent struct, named after
+// the field name.
+func (g *generator) goType(parent *structDef, fieldName string, ty cty.Type) (string, error) {
+	switch {
+	case ty == cty.String:
+		return "string", nil
+	case ty == cty.Number:
+		return "float64", nil
+	case ty == cty.Bool:
+		return "bool", nil
+	case ty == cty.DynamicPseudoType:
+		return "interface{}", nil
+	case ty.IsListType(), ty.IsSetType():
+		ety, err := g.goType(parent, fieldName, ty.ElementType())
+		if err != nil {
+			return "", err
+		}
+		return "[]" + ety, nil
+	case ty.IsMapType():
+		ety, err := g.goType(parent, fieldName, ty.ElementType())
+		if err != nil {
+			return "", err
+		}
+		return "map[string]" + ety, nil
+	case ty.IsTupleType():
+		return "[]interface{}", nil
+	case ty.IsObjectType():
+		sub := g.newStruct(parent, fieldName)
+		names := make([]string, 0, len(ty.AttributeTypes()))
+		for name := range ty.AttributeTypes() {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fname := goName(name)
+			typ, err := g.goType(sub, fname, ty.AttributeType(name))
+			if err != nil {
+				return "", err
+			}
+			f := field{name: fname, typ: typ, tagName: name}
+			if ty.AttributeOptional(name) {
+				f.typ = optionalType(typ)
+				f.omitEmpty = true
+			}
+			if err := g.addField(sub, f); err != nil {
+				return "", err
+			}
+		}
+		return sub.name, nil
+	default:
+		return "", fmt.Errorf("%s.%s: unsupported type %s", parent.name, fieldName, ty.FriendlyName())
+	}
+}
+
+// optionalType returns the Go type of an optional value of the type, which is a pointer unless the type is nilable.
+func optionalType(typ string) string {
+	if strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[") || typ == "interface{}" {
+		return typ
+	}
+	return "*" + typ
+}
+
+// description returns the doc comment of a field, with the deprecation message (if any) as the "Deprecated:" paragraph.
+func description(desc, deprecationMessage string) string {
+	desc = strings.TrimSpace(desc)
+	if deprecationMessage != "" {
+		if desc != "" {
+			desc += "\n\n"
+		}
+		desc += "Deprecated: " + deprecationMessage
+	}
+	return desc
+}
+
+// commonInitialisms are the initialisms that are kept upper cased in the Go names, following the Go naming convention.
+var commonInitialisms = map[string]bool{
+	"acl": true, "api": true, "arn": true, "cpu": true, "dns": true, "http": true, "https": true, "id": true,
+	"ip": true, "json": true, "sql": true, "ssh": true, "tls": true, "ttl": true, "uri": true, "url": true,
+	"uuid": true, "vm": true, "xml": true,
+}
+
+// goName converts a schema name (e.g. "subnet_id") to an exported Go name (e.g. "SubnetID").
+func goName(name string) string {
+	var sb strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if commonInitialisms[strings.ToLower(part)] {
+			sb.WriteString(strings.ToUpper(part))
+			continue
+		}
+		sb.WriteString(upperFirst(part))
+	}
+	out := sb.String()
+	if out == "" || !unicode.IsLetter([]rune(out)[0]) {
+		out = "X" + out
+	}
+	return out
+}
+
+func upperFirst(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func lowerFirst(s string) string {
+	r := []rune(s)
+	if len(r) == 0 {
+		return s
+	}
+	// Keep the initialisms (e.g. "ID of ...") as is.
+	if len(r) > 1 && unicode.IsUpper(r[1]) {
+		return s
+	}
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
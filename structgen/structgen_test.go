@@ -0,0 +1,159 @@
+package structgen_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/magodo/tfpluginschema/structgen"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var update = flag.Bool("update", false, "Update the golden files")
+
+func ToPtr[T any](v T) *T {
+	return &v
+}
+
+func TestGenerate(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema *schema.Schema
+		opts   structgen.Options
+	}{
+		{
+			name: "resource",
+			schema: &schema.Schema{
+				Description: "Manages a virtual machine.",
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{Name: "id", Type: ToPtr(cty.String), Computed: true},
+						{Name: "name", Type: ToPtr(cty.String), Required: true, Description: "The name of the virtual machine."},
+						{Name: "size", Type: ToPtr(cty.Number), Optional: true},
+						{Name: "enabled", Type: ToPtr(cty.Bool), Optional: true, Computed: true},
+						{Name: "zones", Type: ToPtr(cty.Set(cty.String)), Optional: true},
+						{Name: "tags", Type: ToPtr(cty.Map(cty.String)), Optional: true, DeprecationMessage: "Use labels instead."},
+						{Name: "labels", Type: ToPtr(cty.Map(cty.String)), Required: true},
+						{Name: "payload", Type: ToPtr(cty.DynamicPseudoType), Optional: true},
+						{Name: "tuple", Type: ToPtr(cty.Tuple([]cty.Type{cty.String, cty.Number})), Optional: true},
+						{
+							Name: "endpoint",
+							Type: ToPtr(cty.ObjectWithOptionalAttrs(map[string]cty.Type{
+								"url":  cty.String,
+								"port": cty.Number,
+							}, []string{"port"})),
+							Required: true,
+						},
+						{
+							Name:     "ip_rules",
+							Optional: true,
+							NestedType: &schema.SchemaObject{
+								Nesting: schema.SchemaObjectNestingModeList,
+								Attributes: []*schema.SchemaAttribute{
+									{Name: "cidr", Type: ToPtr(cty.String), Required: true},
+									{Name: "ports", Type: ToPtr(cty.List(cty.Number)), Optional: true},
+								},
+							},
+						},
+						{
+							Name:     "identity",
+							Optional: true,
+							NestedType: &schema.SchemaObject{
+								Nesting: schema.SchemaObjectNestingModeSingle,
+								Attributes: []*schema.SchemaAttribute{
+									{Name: "type", Type: ToPtr(cty.String), Required: true},
+								},
+							},
+						},
+					},
+					BlockTypes: []*schema.SchemaNestedBlock{
+						{
+							TypeName: "network_interface",
+							Nesting:  schema.SchemaNestedBlockNestingModeList,
+							MinItems: 1,
+							Block: &schema.SchemaBlock{
+								Attributes: []*schema.SchemaAttribute{
+									{Name: "subnet_id", Type: ToPtr(cty.String), Required: true},
+								},
+								BlockTypes: []*schema.SchemaNestedBlock{
+									{
+										TypeName: "ip_configuration",
+										Nesting:  schema.SchemaNestedBlockNestingModeMap,
+										Block: &schema.SchemaBlock{
+											Attributes: []*schema.SchemaAttribute{
+												{Name: "private_ip", Type: ToPtr(cty.String), Optional: true},
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							TypeName: "os_disk",
+							Nesting:  schema.SchemaNestedBlockNestingModeSingle,
+							Required: ToPtr(true),
+							Block: &schema.SchemaBlock{
+								Attributes: []*schema.SchemaAttribute{
+									{Name: "size_gb", Type: ToPtr(cty.Number), Optional: true},
+								},
+							},
+						},
+						{
+							TypeName: "timeouts",
+							Nesting:  schema.SchemaNestedBlockNestingModeSingle,
+							Block: &schema.SchemaBlock{
+								Attributes: []*schema.SchemaAttribute{
+									{Name: "create", Type: ToPtr(cty.String), Optional: true},
+								},
+							},
+						},
+						{
+							TypeName: "features",
+							Nesting:  schema.SchemaNestedBlockNestingModeGroup,
+							Block:    &schema.SchemaBlock{},
+						},
+					},
+				},
+			},
+			opts: structgen.Options{PackageName: "compute", TypeName: "VirtualMachine"},
+		},
+		{
+			name:   "empty",
+			schema: &schema.Schema{},
+			opts:   structgen.Options{TypeName: "Empty"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := structgen.Generate(tt.schema, tt.opts)
+			require.NoError(t, err)
+
+			golden := filepath.Join("testdata", tt.name+".golden")
+			if *update {
+				require.NoError(t, os.WriteFile(golden, b, 0644))
+			}
+			expect, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			require.Equal(t, string(expect), string(b))
+		})
+	}
+}
+
+func TestGenerateError(t *testing.T) {
+	_, err := structgen.Generate(&schema.Schema{}, structgen.Options{})
+	require.ErrorContains(t, err, "type name is required")
+
+	_, err = structgen.Generate(&schema.Schema{
+		Block: &schema.SchemaBlock{
+			Attributes: []*schema.SchemaAttribute{
+				{Name: "foo_bar", Type: ToPtr(cty.String), Optional: true},
+				{Name: "foo-bar", Type: ToPtr(cty.String), Optional: true},
+			},
+		},
+	}, structgen.Options{TypeName: "Foo"})
+	require.ErrorContains(t, err, `Foo: "foo_bar" and "foo-bar" are both mapped to the field FooBar`)
+}
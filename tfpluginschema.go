@@ -1,28 +1,177 @@
 package tfpluginschema
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/magodo/tfpluginschema/internal/fw"
+	"github.com/magodo/tfpluginschema/internal/proto"
 	"github.com/magodo/tfpluginschema/internal/sdkv2"
+	"github.com/magodo/tfpluginschema/internal/tfjson"
 	"github.com/magodo/tfpluginschema/schema"
 )
 
 // FromSDKv2Provider converts the provider from the schema defined in the plugin sdk v2 to the schema defined in tfpluginschema.
+// It panics if the provider schema is invalid, use FromSDKv2ProviderE instead to get an error.
 func FromSDKv2Provider(p *sdkschema.Provider) *schema.ProviderSchema {
 	return sdkv2.FromProvider(p)
 }
 
+// FromSDKv2ProviderE is like FromSDKv2Provider, but returns an error if the provider schema is invalid.
+// The returned error joins the problems of all the invalid fields, each of which reports the attribute path.
+// The invalid resources and data sources are omitted from the returned provider schema.
+func FromSDKv2ProviderE(p *sdkschema.Provider) (*schema.ProviderSchema, error) {
+	return sdkv2.FromProviderE(p)
+}
+
 // FromSDKv2Resource converts the resource from the schema defined in the plugin sdk v2 to the schema defined in tfpluginschema.
+// It panics if the resource schema is invalid, use FromSDKv2ResourceE instead to get an error.
 func FromSDKv2Resource(res *sdkschema.Resource) *schema.Schema {
 	return sdkv2.FromResource(res)
 }
 
+// FromSDKv2ResourceE is like FromSDKv2Resource, but returns an error if the resource schema is invalid.
+func FromSDKv2ResourceE(res *sdkschema.Resource) (*schema.Schema, error) {
+	return sdkv2.FromResourceE(res)
+}
+
 // FromSDKv2SchemasMap converts the schema map from the schema defined in the plugin sdk v2 to the schema defined in tfpluginschema.
+// It panics if the schema map is invalid, use FromSDKv2SchemaMapE instead to get an error.
 func FromSDKv2SchemaMap(m map[string]*sdkschema.Schema) *schema.SchemaBlock {
 	return sdkv2.FromSchemaMap(m)
 }
 
+// FromSDKv2SchemaMapE is like FromSDKv2SchemaMap, but returns an error if the schema map is invalid.
+func FromSDKv2SchemaMapE(m map[string]*sdkschema.Schema) (*schema.SchemaBlock, error) {
+	return sdkv2.FromSchemaMapE(m)
+}
+
 func FromFWProvider(p provider.Provider) (*schema.ProviderSchema, error) {
 	return fw.FromProvider(p)
 }
+
+// FromProtoV5ProviderSchema converts the GetProviderSchema response of the protocol v5 to the schema defined in tfpluginschema.
+// The extended properties that are only available in the SDK/FW schema are left unset.
+func FromProtoV5ProviderSchema(resp *tfprotov5.GetProviderSchemaResponse) (*schema.ProviderSchema, error) {
+	return proto.FromV5ProviderSchema(resp)
+}
+
+// FromProtoV6ProviderSchema converts the GetProviderSchema response of the protocol v6 to the schema defined in tfpluginschema.
+// The extended properties that are only available in the SDK/FW schema are left unset.
+func FromProtoV6ProviderSchema(resp *tfprotov6.GetProviderSchemaResponse) (*schema.ProviderSchema, error) {
+	return proto.FromV6ProviderSchema(resp)
+}
+
+// FromProtoV5Functions converts the GetFunctions response of the protocol v5 to the functions defined in tfpluginschema, keyed by the function name.
+func FromProtoV5Functions(resp *tfprotov5.GetFunctionsResponse) (map[string]*schema.Function, error) {
+	return proto.FromV5Functions(resp)
+}
+
+// FromProtoV5Provider gets the provider schema from the protocol v5 provider server, and converts it to the schema defined in tfpluginschema.
+// The functions are got from GetFunctions if they are absent in the GetProviderSchema response.
+func FromProtoV5Provider(ctx context.Context, p tfprotov5.ProviderServer) (*schema.ProviderSchema, error) {
+	resp, err := p.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("getting provider schema: %v", err)
+	}
+	ps, err := proto.FromV5ProviderSchema(resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(ps.Functions) != 0 {
+		return ps, nil
+	}
+	functionsResp, err := p.GetFunctions(ctx, &tfprotov5.GetFunctionsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("getting functions: %v", err)
+	}
+	functions, err := proto.FromV5Functions(functionsResp)
+	if err != nil {
+		return nil, err
+	}
+	ps.Functions = functions
+	return ps, nil
+}
+
+// FromProtoV6Functions converts the GetFunctions response of the protocol v6 to the functions defined in tfpluginschema, keyed by the function name.
+func FromProtoV6Functions(resp *tfprotov6.GetFunctionsResponse) (map[string]*schema.Function, error) {
+	return proto.FromV6Functions(resp)
+}
+
+// FromProtoV6Provider gets the provider schema from the protocol v6 provider server, and converts it to the schema defined in tfpluginschema.
+// The functions are got from GetFunctions if they are absent in the GetProviderSchema response.
+func FromProtoV6Provider(ctx context.Context, p tfprotov6.ProviderServer) (*schema.ProviderSchema, error) {
+	resp, err := p.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("getting provider schema: %v", err)
+	}
+	ps, err := proto.FromV6ProviderSchema(resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(ps.Functions) != 0 {
+		return ps, nil
+	}
+	functionsResp, err := p.GetFunctions(ctx, &tfprotov6.GetFunctionsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("getting functions: %v", err)
+	}
+	functions, err := proto.FromV6Functions(functionsResp)
+	if err != nil {
+		return nil, err
+	}
+	ps.Functions = functions
+	return ps, nil
+}
+
+// ToProtoV5ProviderSchema converts the provider schema defined in tfpluginschema to the GetProviderSchema response of the protocol v5.
+// It returns an error if the schema contains anything that can't be expressed in the protocol v5, e.g. nested attribute types.
+func ToProtoV5ProviderSchema(ps *schema.ProviderSchema) (*tfprotov5.GetProviderSchemaResponse, error) {
+	return proto.ToV5ProviderSchema(ps)
+}
+
+// ToProtoV6ProviderSchema converts the provider schema defined in tfpluginschema to the GetProviderSchema response of the protocol v6.
+func ToProtoV6ProviderSchema(ps *schema.ProviderSchema) (*tfprotov6.GetProviderSchemaResponse, error) {
+	return proto.ToV6ProviderSchema(ps)
+}
+
+// ToProtoV5Schema converts the schema defined in tfpluginschema to the schema of the protocol v5.
+// It returns an error if the schema contains anything that can't be expressed in the protocol v5, e.g. nested attribute types.
+func ToProtoV5Schema(s *schema.Schema) (*tfprotov5.Schema, error) {
+	return proto.ToV5Schema(s)
+}
+
+// ToProtoV6Schema converts the schema defined in tfpluginschema to the schema of the protocol v6.
+func ToProtoV6Schema(s *schema.Schema) (*tfprotov6.Schema, error) {
+	return proto.ToV6Schema(s)
+}
+
+// ToProtoV5SchemaBlock converts the schema block defined in tfpluginschema to the schema block of the protocol v5.
+// It returns an error if the block contains anything that can't be expressed in the protocol v5, e.g. nested attribute types.
+func ToProtoV5SchemaBlock(b *schema.SchemaBlock) (*tfprotov5.SchemaBlock, error) {
+	return proto.ToV5Block(b)
+}
+
+// ToProtoV6SchemaBlock converts the schema block defined in tfpluginschema to the schema block of the protocol v6.
+func ToProtoV6SchemaBlock(b *schema.SchemaBlock) (*tfprotov6.SchemaBlock, error) {
+	return proto.ToV6Block(b)
+}
+
+// FromTerraformProvidersSchemaJSON converts the output of `terraform providers schema -json` to the schemas defined in tfpluginschema,
+// keyed by the provider source address (e.g. registry.terraform.io/hashicorp/azurerm).
+// The tfpluginschema only properties (e.g. default, force_new, conflicts_with, validators) are restored from the "tfpluginschema"
+// extensions when present (see ToTerraformProvidersSchemaJSON), otherwise they are left unset.
+func FromTerraformProvidersSchemaJSON(b []byte) (map[string]*schema.ProviderSchema, error) {
+	return tfjson.FromProviderSchemasJSON(b)
+}
+
+// ToTerraformProvidersSchemaJSON converts the schemas defined in tfpluginschema, keyed by the provider source address, to the output format of
+// `terraform providers schema -json`. The tfpluginschema only properties (e.g. default, force_new, conflicts_with) are written under the
+// "tfpluginschema" key of each schema, attribute and block type, only if withExtensions is true.
+func ToTerraformProvidersSchemaJSON(schemas map[string]*schema.ProviderSchema, withExtensions bool) ([]byte, error) {
+	return tfjson.ToProviderSchemasJSON(schemas, withExtensions)
+}
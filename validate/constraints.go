@@ -0,0 +1,106 @@
+package validate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// constraints are the SDKv2 style cross-attribute constraints, whose keys are in form of the SDKv2 schema keys (e.g. "block.0.attr").
+type constraints struct {
+	conflictsWith []string
+	exactlyOneOf  []string
+	atLeastOneOf  []string
+	requiredWith  []string
+}
+
+type constraintViolation struct {
+	summary string
+	detail  string
+}
+
+// check checks the constraints of a schema key, following the SDKv2 semantics: ExactlyOneOf and AtLeastOneOf are checked regardless,
+// while ConflictsWith and RequiredWith are only checked when the key is set. The present function tells whether a key is set.
+func (c constraints) check(key string, keySet bool, present func(key string) bool) []constraintViolation {
+	var violations []constraintViolation
+
+	if len(c.exactlyOneOf) != 0 {
+		keys := uniqueSorted(c.exactlyOneOf)
+		var specified []string
+		for _, k := range keys {
+			if present(k) {
+				specified = append(specified, k)
+			}
+		}
+		switch {
+		case len(specified) == 0:
+			violations = append(violations, constraintViolation{
+				summary: "Invalid combination of arguments",
+				detail:  fmt.Sprintf("%q: one of `%s` must be specified", key, strings.Join(keys, ",")),
+			})
+		case len(specified) > 1:
+			violations = append(violations, constraintViolation{
+				summary: "Invalid combination of arguments",
+				detail:  fmt.Sprintf("%q: only one of `%s` can be specified, but `%s` were specified.", key, strings.Join(keys, ","), strings.Join(specified, ",")),
+			})
+		}
+	}
+
+	if len(c.atLeastOneOf) != 0 {
+		keys := uniqueSorted(c.atLeastOneOf)
+		found := false
+		for _, k := range keys {
+			if present(k) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			violations = append(violations, constraintViolation{
+				summary: "Missing required argument",
+				detail:  fmt.Sprintf("%q: one of `%s` must be specified", key, strings.Join(keys, ",")),
+			})
+		}
+	}
+
+	if !keySet {
+		return violations
+	}
+
+	if len(c.requiredWith) != 0 {
+		keys := uniqueSorted(append([]string{key}, c.requiredWith...))
+		for _, k := range keys {
+			if !present(k) {
+				violations = append(violations, constraintViolation{
+					summary: "Missing required argument",
+					detail:  fmt.Sprintf("%q: all of `%s` must be specified", key, strings.Join(keys, ",")),
+				})
+				break
+			}
+		}
+	}
+
+	for _, k := range c.conflictsWith {
+		if k != key && present(k) {
+			violations = append(violations, constraintViolation{
+				summary: "Conflicting configuration arguments",
+				detail:  fmt.Sprintf("%q: conflicts with %s", key, k),
+			})
+		}
+	}
+
+	return violations
+}
+
+func uniqueSorted(l []string) []string {
+	m := map[string]bool{}
+	var out []string
+	for _, s := range l {
+		if !m[s] {
+			m[s] = true
+			out = append(out, s)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,330 @@
+// Package validate validates the configurations against the schema defined in tfpluginschema.
+package validate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// The meta-arguments of the resource and data source blocks, which are not defined in the schema.
+var (
+	metaAttributes = []string{"count", "for_each", "depends_on", "provider"}
+	metaBlocks     = []hcl.BlockHeaderSchema{
+		{Type: "lifecycle"},
+		{Type: "provisioner", LabelNames: []string{"type"}},
+		{Type: "connection"},
+	}
+)
+
+// Body validates the HCL body of a resource or data source configuration against the schema.
+//
+// It checks the unknown arguments and blocks, the missing required arguments, the block nesting, the number of
+// blocks and the types of the argument values that don't reference anything (e.g. literals).
+// It also checks the SDKv2 style constraints: ConflictsWith, ExactlyOneOf, AtLeastOneOf and RequiredWith,
+// whose keys are in form of the SDKv2 schema keys (e.g. "block.0.attr").
+//
+// The Terraform meta-arguments (e.g. count, lifecycle) are allowed at the top level.
+// The contents of the dynamic blocks are not validated, as they are only known after expansion.
+func Body(body hcl.Body, sch *schema.Schema) hcl.Diagnostics {
+	var blk *schema.SchemaBlock
+	if sch != nil {
+		blk = sch.Block
+	}
+	if blk == nil {
+		blk = &schema.SchemaBlock{}
+	}
+
+	d := &decoder{present: map[string]bool{}}
+	root := d.decode(body, blk, "", true)
+	diags := d.diags
+	diags = append(diags, d.checkConstraints(root)...)
+	return diags
+}
+
+// decodedBody is a decoded configuration body, together with its schema.
+type decodedBody struct {
+	block *schema.SchemaBlock
+	// prefix is the SDKv2 schema key prefix of the body, e.g. "block.0.".
+	prefix       string
+	missingRange hcl.Range
+	attrs        hcl.Attributes
+	// blocks are the decoded nested blocks, keyed by the block type.
+	blocks map[string][]*decodedBody
+	// blockRanges are the definition ranges of the nested blocks (including the dynamic blocks), keyed by the block type.
+	blockRanges map[string][]hcl.Range
+}
+
+type decoder struct {
+	diags hcl.Diagnostics
+	// present records the SDKv2 schema keys that are set in the configuration.
+	present map[string]bool
+}
+
+func (d *decoder) decode(body hcl.Body, blk *schema.SchemaBlock, prefix string, root bool) *decodedBody {
+	bodySchema := &hcl.BodySchema{}
+	names := map[string]bool{}
+	for _, attr := range blk.Attributes {
+		names[attr.Name] = true
+		bodySchema.Attributes = append(bodySchema.Attributes, hcl.AttributeSchema{Name: attr.Name, Required: attr.Required})
+	}
+	for _, nb := range blk.BlockTypes {
+		names[nb.TypeName] = true
+		header := hcl.BlockHeaderSchema{Type: nb.TypeName}
+		if nb.Nesting == schema.SchemaNestedBlockNestingModeMap {
+			header.LabelNames = []string{"key"}
+		}
+		bodySchema.Blocks = append(bodySchema.Blocks, header)
+	}
+	if root {
+		for _, name := range metaAttributes {
+			if !names[name] {
+				bodySchema.Attributes = append(bodySchema.Attributes, hcl.AttributeSchema{Name: name})
+			}
+		}
+		for _, header := range metaBlocks {
+			if !names[header.Type] {
+				bodySchema.Blocks = append(bodySchema.Blocks, header)
+			}
+		}
+	}
+	if !names["dynamic"] {
+		bodySchema.Blocks = append(bodySchema.Blocks, hcl.BlockHeaderSchema{Type: "dynamic", LabelNames: []string{"type"}})
+	}
+
+	content, diags := body.Content(bodySchema)
+	d.diags = append(d.diags, diags...)
+
+	out := &decodedBody{
+		block:        blk,
+		prefix:       prefix,
+		missingRange: body.MissingItemRange(),
+		attrs:        hcl.Attributes{},
+		blocks:       map[string][]*decodedBody{},
+		blockRanges:  map[string][]hcl.Range{},
+	}
+
+	attrs := blk.Attributes.Map()
+	for name, hclAttr := range content.Attributes {
+		attr, ok := attrs[name]
+		if !ok {
+			// Meta-arguments
+			continue
+		}
+		out.attrs[name] = hclAttr
+		d.checkAttribute(hclAttr, attr)
+		if !isNullExpr(hclAttr.Expr) {
+			d.present[prefix+name] = true
+		}
+	}
+
+	nestedBlocks := blk.BlockTypes.Map()
+	for _, hclBlock := range content.Blocks {
+		typeName := hclBlock.Type
+		if typeName == "dynamic" && !names["dynamic"] {
+			typeName = hclBlock.Labels[0]
+			if _, ok := nestedBlocks[typeName]; !ok {
+				d.diags = append(d.diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Unsupported block type",
+					Detail:   fmt.Sprintf("Blocks of type %q are not expected here.", typeName),
+					Subject:  hclBlock.LabelRanges[0].Ptr(),
+				})
+				continue
+			}
+			out.blockRanges[typeName] = append(out.blockRanges[typeName], hclBlock.DefRange)
+			d.present[prefix+typeName] = true
+			continue
+		}
+		nb, ok := nestedBlocks[typeName]
+		if !ok {
+			// Meta-blocks
+			continue
+		}
+
+		idx := len(out.blocks[typeName])
+		var elemPrefix string
+		switch nb.Nesting {
+		case schema.SchemaNestedBlockNestingModeList, schema.SchemaNestedBlockNestingModeSet:
+			elemPrefix = prefix + typeName + "." + strconv.Itoa(idx) + "."
+		case schema.SchemaNestedBlockNestingModeMap:
+			elemPrefix = prefix + typeName + "." + hclBlock.Labels[0] + "."
+		default:
+			elemPrefix = prefix + typeName + "."
+		}
+		d.present[prefix+typeName] = true
+		d.present[strings.TrimSuffix(elemPrefix, ".")] = true
+
+		nbBlock := nb.Block
+		if nbBlock == nil {
+			nbBlock = &schema.SchemaBlock{}
+		}
+		elem := d.decode(hclBlock.Body, nbBlock, elemPrefix, false)
+		out.blocks[typeName] = append(out.blocks[typeName], elem)
+		out.blockRanges[typeName] = append(out.blockRanges[typeName], hclBlock.DefRange)
+	}
+
+	for _, nb := range blk.BlockTypes {
+		d.checkNestedBlock(out, nb)
+	}
+
+	return out
+}
+
+// checkAttribute checks whether the attribute can be set, and whether its value conforms to the type.
+func (d *decoder) checkAttribute(hclAttr *hcl.Attribute, attr *schema.SchemaAttribute) {
+	if attr.Computed && !attr.Optional && !attr.Required {
+		d.diags = append(d.diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Value for unconfigurable attribute",
+			Detail:   fmt.Sprintf("Can't configure a value for %q: its value will be decided automatically based on the result of applying this configuration.", attr.Name),
+			Subject:  hclAttr.NameRange.Ptr(),
+		})
+		return
+	}
+
+	if len(hclAttr.Expr.Variables()) != 0 {
+		return
+	}
+	val, diags := hclAttr.Expr.Value(nil)
+	if diags.HasErrors() {
+		// The expression might contain function calls, which can't be evaluated here.
+		return
+	}
+	ty, err := attr.ConfigType()
+	if err != nil {
+		return
+	}
+	if attr.Required && val.IsNull() {
+		d.diags = append(d.diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Missing required argument",
+			Detail:   fmt.Sprintf("The argument %q is required, but no definition was found.", attr.Name),
+			Subject:  hclAttr.Expr.Range().Ptr(),
+		})
+		return
+	}
+	if _, err := convert.Convert(val, ty); err != nil {
+		d.diags = append(d.diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Incorrect attribute value type",
+			Detail:   fmt.Sprintf("Inappropriate value for attribute %q: %s.", attr.Name, convertErrorMessage(err)),
+			Subject:  hclAttr.Expr.Range().Ptr(),
+		})
+	}
+}
+
+// checkNestedBlock checks the number of the nested blocks, and whether the nested block can be set.
+func (d *decoder) checkNestedBlock(body *decodedBody, nb *schema.SchemaNestedBlock) {
+	ranges := body.blockRanges[nb.TypeName]
+	n := len(ranges)
+	hasDynamic := n != len(body.blocks[nb.TypeName])
+
+	if n != 0 && boolValue(nb.Computed) && !boolValue(nb.Optional) && !boolValue(nb.Required) {
+		d.diags = append(d.diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Unconfigurable block",
+			Detail:   fmt.Sprintf("Can't configure the block %q: its value will be decided automatically based on the result of applying this configuration.", nb.TypeName),
+			Subject:  ranges[0].Ptr(),
+		})
+		return
+	}
+
+	minItems := nb.MinItems
+	if boolValue(nb.Required) && minItems == 0 {
+		minItems = 1
+	}
+	maxItems := nb.MaxItems
+	if nb.Nesting == schema.SchemaNestedBlockNestingModeSingle || nb.Nesting == schema.SchemaNestedBlockNestingModeGroup {
+		maxItems = 1
+	}
+
+	switch {
+	case n < minItems && !hasDynamic:
+		d.diags = append(d.diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Insufficient " + nb.TypeName + " blocks",
+			Detail:   fmt.Sprintf("At least %d %q blocks are required.", minItems, nb.TypeName),
+			Subject:  body.missingRange.Ptr(),
+		})
+	case maxItems > 0 && n > maxItems && !hasDynamic:
+		d.diags = append(d.diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Too many " + nb.TypeName + " blocks",
+			Detail:   fmt.Sprintf("No more than %d %q blocks are allowed.", maxItems, nb.TypeName),
+			Subject:  ranges[maxItems].Ptr(),
+		})
+	}
+}
+
+// checkConstraints checks the SDKv2 style constraints of the attributes and nested blocks, recursively.
+func (d *decoder) checkConstraints(body *decodedBody) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	for _, attr := range body.block.Attributes {
+		var subject *hcl.Range
+		if hclAttr, ok := body.attrs[attr.Name]; ok {
+			subject = hclAttr.NameRange.Ptr()
+		}
+		diags = append(diags, d.checkKeyConstraints(body, body.prefix+attr.Name, subject, constraints{
+			conflictsWith: attr.ConflictsWith,
+			exactlyOneOf:  attr.ExactlyOneOf,
+			atLeastOneOf:  attr.AtLeastOneOf,
+			requiredWith:  attr.RequiredWith,
+		})...)
+	}
+	for _, nb := range body.block.BlockTypes {
+		var subject *hcl.Range
+		if ranges := body.blockRanges[nb.TypeName]; len(ranges) != 0 {
+			subject = ranges[0].Ptr()
+		}
+		diags = append(diags, d.checkKeyConstraints(body, body.prefix+nb.TypeName, subject, constraints{
+			conflictsWith: nb.ConflictsWith,
+			exactlyOneOf:  nb.ExactlyOneOf,
+			atLeastOneOf:  nb.AtLeastOneOf,
+			requiredWith:  nb.RequiredWith,
+		})...)
+		for _, elem := range body.blocks[nb.TypeName] {
+			diags = append(diags, d.checkConstraints(elem)...)
+		}
+	}
+	return diags
+}
+
+// checkKeyConstraints checks the constraints of a schema key. The subject is nil if the key is not set.
+func (d *decoder) checkKeyConstraints(body *decodedBody, key string, subject *hcl.Range, c constraints) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	keySet := subject != nil && d.present[key]
+	for _, violation := range c.check(key, keySet, func(k string) bool { return d.present[k] }) {
+		diag := &hcl.Diagnostic{Severity: hcl.DiagError, Summary: violation.summary, Detail: violation.detail, Subject: subject}
+		if diag.Subject == nil {
+			diag.Subject = body.missingRange.Ptr()
+		}
+		diags = append(diags, diag)
+	}
+	return diags
+}
+
+// isNullExpr tells whether the expression is a literal null.
+func isNullExpr(expr hcl.Expression) bool {
+	if len(expr.Variables()) != 0 {
+		return false
+	}
+	val, diags := expr.Value(nil)
+	return !diags.HasErrors() && val.IsNull()
+}
+
+func convertErrorMessage(err error) string {
+	if pathErr, ok := err.(cty.PathError); ok && len(pathErr.Path) != 0 {
+		return fmt.Sprintf("%s: %s", formatCtyPath(pathErr.Path), pathErr.Error())
+	}
+	return err.Error()
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
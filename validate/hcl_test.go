@@ -0,0 +1,307 @@
+package validate_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/magodo/tfpluginschema/validate"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func ToPtr[T any](v T) *T {
+	return &v
+}
+
+var testSchema = &schema.Schema{
+	Block: &schema.SchemaBlock{
+		Attributes: []*schema.SchemaAttribute{
+			{Name: "name", Type: ToPtr(cty.String), Required: true},
+			{Name: "id", Type: ToPtr(cty.String), Computed: true},
+			{Name: "size", Type: ToPtr(cty.Number), Optional: true, ExactlyOneOf: []string{"size", "sku"}},
+			{Name: "sku", Type: ToPtr(cty.String), Optional: true, ExactlyOneOf: []string{"size", "sku"}},
+			{Name: "username", Type: ToPtr(cty.String), Optional: true, RequiredWith: []string{"password"}},
+			{Name: "password", Type: ToPtr(cty.String), Optional: true, Sensitive: true},
+			{Name: "tags", Type: ToPtr(cty.Map(cty.String)), Optional: true},
+			{Name: "zone", Type: ToPtr(cty.String), Optional: true, ConflictsWith: []string{"network.0.zones"}},
+		},
+		BlockTypes: []*schema.SchemaNestedBlock{
+			{
+				TypeName: "network",
+				Nesting:  schema.SchemaNestedBlockNestingModeList,
+				MinItems: 1,
+				MaxItems: 1,
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{Name: "zones", Type: ToPtr(cty.List(cty.String)), Optional: true},
+						{Name: "subnet_id", Type: ToPtr(cty.String), Optional: true, AtLeastOneOf: []string{"network.0.subnet_id", "network.0.vnet_id"}},
+						{Name: "vnet_id", Type: ToPtr(cty.String), Optional: true, AtLeastOneOf: []string{"network.0.subnet_id", "network.0.vnet_id"}},
+					},
+				},
+			},
+			{
+				TypeName: "rule",
+				Nesting:  schema.SchemaNestedBlockNestingModeSet,
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{Name: "priority", Type: ToPtr(cty.Number), Required: true},
+					},
+				},
+			},
+		},
+	},
+}
+
+var testNestedSchema = &schema.Schema{
+	Block: &schema.SchemaBlock{
+		Attributes: []*schema.SchemaAttribute{
+			{
+				Name:     "endpoints",
+				Optional: true,
+				NestedType: &schema.SchemaObject{
+					Nesting: schema.SchemaObjectNestingModeList,
+					Attributes: []*schema.SchemaAttribute{
+						{Name: "host", Type: ToPtr(cty.String), Required: true},
+						{Name: "port", Type: ToPtr(cty.Number), Optional: true},
+					},
+				},
+			},
+		},
+	},
+}
+
+func parseBody(t *testing.T, src string) hcl.Body {
+	f, diags := hclsyntax.ParseConfig([]byte(src), "main.tf", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+	return f.Body
+}
+
+func TestBody(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema *schema.Schema
+		config string
+		// expect is a list of "<line>: <summary>: <detail>" of the diagnostics.
+		expect []string
+	}{
+		{
+			name: "valid",
+			config: `
+name     = "foo"
+size     = var.size
+username = "admin"
+password = "secret"
+tags = {
+  env = "prod"
+}
+count = 2
+network {
+  subnet_id = "x"
+}
+rule {
+  priority = 1
+}
+rule {
+  priority = 2
+}
+lifecycle {
+  ignore_changes = [tags]
+}
+`,
+		},
+		{
+			name: "unknown argument and block",
+			config: `
+name = "foo"
+size = 1
+foo  = "bar"
+network {
+  subnet_id = "x"
+}
+bar {}
+`,
+			expect: []string{
+				`4: Unsupported argument: An argument named "foo" is not expected here.`,
+				`8: Unsupported block type: Blocks of type "bar" are not expected here.`,
+			},
+		},
+		{
+			name: "missing required argument",
+			config: `
+size = 1
+network {
+  subnet_id = "x"
+}
+rule {}
+`,
+			expect: []string{
+				`1: Missing required argument: The argument "name" is required, but no definition was found.`,
+				`6: Missing required argument: The argument "priority" is required, but no definition was found.`,
+			},
+		},
+		{
+			name: "wrong block nesting",
+			config: `
+name = "foo"
+size = 1
+network = {
+  subnet_id = "x"
+}
+`,
+			expect: []string{
+				`4: Unsupported argument: An argument named "network" is not expected here. Did you mean to define a block of type "network"?`,
+				`1: Insufficient network blocks: At least 1 "network" blocks are required.`,
+			},
+		},
+		{
+			name: "too many blocks",
+			config: `
+name = "foo"
+size = 1
+network {
+  subnet_id = "x"
+}
+network {
+  subnet_id = "y"
+}
+`,
+			expect: []string{
+				`7: Too many network blocks: No more than 1 "network" blocks are allowed.`,
+			},
+		},
+		{
+			name: "unconfigurable and wrong type",
+			config: `
+name = "foo"
+id   = "bar"
+size = "large"
+tags = ["a"]
+network {
+  subnet_id = "x"
+}
+`,
+			expect: []string{
+				`3: Value for unconfigurable attribute: Can't configure a value for "id": its value will be decided automatically based on the result of applying this configuration.`,
+				`4: Incorrect attribute value type: Inappropriate value for attribute "size": a number is required.`,
+				`5: Incorrect attribute value type: Inappropriate value for attribute "tags": map of string required.`,
+			},
+		},
+		{
+			name: "exactly one of",
+			config: `
+name = "foo"
+size = 1
+sku  = "small"
+network {
+  subnet_id = "x"
+}
+`,
+			expect: []string{
+				"3: Invalid combination of arguments: \"size\": only one of `size,sku` can be specified, but `size,sku` were specified.",
+				"4: Invalid combination of arguments: \"sku\": only one of `size,sku` can be specified, but `size,sku` were specified.",
+			},
+		},
+		{
+			name: "exactly one of none",
+			config: `
+name = "foo"
+size = null
+network {
+  subnet_id = "x"
+}
+`,
+			expect: []string{
+				"3: Invalid combination of arguments: \"size\": one of `size,sku` must be specified",
+				"1: Invalid combination of arguments: \"sku\": one of `size,sku` must be specified",
+			},
+		},
+		{
+			name: "at least one of",
+			config: `
+name = "foo"
+size = 1
+network {
+}
+`,
+			expect: []string{
+				"4: Missing required argument: \"network.0.subnet_id\": one of `network.0.subnet_id,network.0.vnet_id` must be specified",
+				"4: Missing required argument: \"network.0.vnet_id\": one of `network.0.subnet_id,network.0.vnet_id` must be specified",
+			},
+		},
+		{
+			name: "required with and conflicts with",
+			config: `
+name     = "foo"
+size     = 1
+username = "admin"
+zone     = "1"
+network {
+  subnet_id = "x"
+  zones     = ["1"]
+}
+`,
+			expect: []string{
+				"4: Missing required argument: \"username\": all of `password,username` must be specified",
+				"5: Conflicting configuration arguments: \"zone\": conflicts with network.0.zones",
+			},
+		},
+		{
+			name:   "nested attribute with optional attribute omitted",
+			schema: testNestedSchema,
+			config: `
+endpoints = [
+  { host = "a" },
+  { host = "b", port = 80 },
+]
+`,
+		},
+		{
+			name:   "nested attribute with required attribute omitted",
+			schema: testNestedSchema,
+			config: `
+endpoints = [{ port = 80 }]
+`,
+			expect: []string{
+				`2: Incorrect attribute value type: Inappropriate value for attribute "endpoints": element 0: attribute "host" is required.`,
+			},
+		},
+		{
+			name: "dynamic block",
+			config: `
+name = "foo"
+size = 1
+dynamic "network" {
+  for_each = var.networks
+  content {
+    subnet_id = network.value
+  }
+}
+dynamic "foo" {
+  for_each = var.foos
+  content {}
+}
+`,
+			expect: []string{
+				`10: Unsupported block type: Blocks of type "foo" are not expected here.`,
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			sch := tt.schema
+			if sch == nil {
+				sch = testSchema
+			}
+			diags := validate.Body(parseBody(t, tt.config), sch)
+			var got []string
+			for _, diag := range diags {
+				got = append(got, fmt.Sprintf("%d: %s: %s", diag.Subject.Start.Line, diag.Summary, diag.Detail))
+			}
+			require.ElementsMatch(t, tt.expect, got)
+		})
+	}
+}
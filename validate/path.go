@@ -0,0 +1,31 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+// formatCtyPath formats the cty path in the Terraform style, e.g. `.foo[0]["bar"]`.
+func formatCtyPath(path cty.Path) string {
+	var sb strings.Builder
+	for _, step := range path {
+		switch step := step.(type) {
+		case cty.GetAttrStep:
+			sb.WriteString("." + step.Name)
+		case cty.IndexStep:
+			switch {
+			case !step.Key.IsKnown() || step.Key.IsNull():
+				sb.WriteString("[...]")
+			case step.Key.Type() == cty.String:
+				sb.WriteString(fmt.Sprintf("[%q]", step.Key.AsString()))
+			case step.Key.Type() == cty.Number:
+				sb.WriteString("[" + step.Key.AsBigFloat().Text('f', -1) + "]")
+			default:
+				sb.WriteString("[...]")
+			}
+		}
+	}
+	return sb.String()
+}
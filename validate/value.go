@@ -0,0 +1,246 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Value validates the value of a resource or data source against the schema.
+//
+// It checks the value conforms to the implied type of the schema, the required attributes are not null, the number of
+// the nested blocks, and the SDKv2 style constraints: ConflictsWith, ExactlyOneOf, AtLeastOneOf and RequiredWith,
+// whose keys (e.g. "block.0.attr") are resolved against the value. A null value or an empty nested block collection
+// is regarded as not set, while an unknown value is regarded as set. The marks (e.g. sensitive) of the value are
+// ignored.
+//
+// The returned error joins all the problems, each of which is a cty.PathError.
+func Value(val cty.Value, sch *schema.Schema) error {
+	var blk *schema.SchemaBlock
+	if sch != nil {
+		blk = sch.Block
+	}
+	if blk == nil {
+		blk = &schema.SchemaBlock{}
+	}
+	// The marks don't affect the validation, while the marked values can't be iterated.
+	val, _ = val.UnmarkDeep()
+
+	ty, err := blk.ImpliedType()
+	if err != nil {
+		return fmt.Errorf("implying the schema type: %v", err)
+	}
+	if errs := val.Type().TestConformance(ty); len(errs) != 0 {
+		return errors.Join(errs...)
+	}
+	if !val.IsKnown() {
+		return nil
+	}
+	if val.IsNull() {
+		return cty.Path{}.NewErrorf("value is null")
+	}
+
+	v := &valueValidator{root: val}
+	v.block(nil, "", val, blk)
+	return errors.Join(v.errs...)
+}
+
+type valueValidator struct {
+	root cty.Value
+	errs []error
+}
+
+// block validates the known and non-null object value of the block.
+func (v *valueValidator) block(path cty.Path, prefix string, val cty.Value, blk *schema.SchemaBlock) {
+	v.attributes(path, prefix, val, blk.Attributes)
+
+	for _, nb := range blk.BlockTypes {
+		bpath := path.Copy().GetAttr(nb.TypeName)
+		bval := val.GetAttr(nb.TypeName)
+		key := prefix + nb.TypeName
+
+		v.constraints(bpath, key, present(bval), constraints{
+			conflictsWith: nb.ConflictsWith,
+			exactlyOneOf:  nb.ExactlyOneOf,
+			atLeastOneOf:  nb.AtLeastOneOf,
+			requiredWith:  nb.RequiredWith,
+		})
+
+		if !bval.IsKnown() {
+			continue
+		}
+
+		nbBlock := nb.Block
+		if nbBlock == nil {
+			nbBlock = &schema.SchemaBlock{}
+		}
+
+		var n int
+		switch nb.Nesting {
+		case schema.SchemaNestedBlockNestingModeSingle, schema.SchemaNestedBlockNestingModeGroup:
+			if !bval.IsNull() {
+				n = 1
+				v.block(bpath, key+".", bval, nbBlock)
+			}
+		default:
+			if bval.IsNull() {
+				break
+			}
+			length := bval.Length()
+			if !length.IsKnown() {
+				// The length of a set with unknown elements is unknown.
+				continue
+			}
+			l, _ := length.AsBigFloat().Int64()
+			n = int(l)
+			v.elements(bpath, key, bval, func(epath cty.Path, eprefix string, eval cty.Value) {
+				v.block(epath, eprefix, eval, nbBlock)
+			})
+		}
+
+		minItems := nb.MinItems
+		if boolValue(nb.Required) && minItems == 0 {
+			minItems = 1
+		}
+		if n < minItems {
+			v.errs = append(v.errs, bpath.NewErrorf("at least %d %q blocks are required, got %d", minItems, nb.TypeName, n))
+		}
+		if nb.MaxItems > 0 && n > nb.MaxItems {
+			v.errs = append(v.errs, bpath.NewErrorf("no more than %d %q blocks are allowed, got %d", nb.MaxItems, nb.TypeName, n))
+		}
+	}
+}
+
+// attributes validates the attributes of the known and non-null object value.
+func (v *valueValidator) attributes(path cty.Path, prefix string, val cty.Value, attrs schema.SchemaAttributes) {
+	for _, attr := range attrs {
+		apath := path.Copy().GetAttr(attr.Name)
+		aval := val.GetAttr(attr.Name)
+		key := prefix + attr.Name
+
+		if attr.Required && aval.IsNull() {
+			v.errs = append(v.errs, apath.NewErrorf("attribute %q is required", attr.Name))
+		}
+
+		v.constraints(apath, key, present(aval), constraints{
+			conflictsWith: attr.ConflictsWith,
+			exactlyOneOf:  attr.ExactlyOneOf,
+			atLeastOneOf:  attr.AtLeastOneOf,
+			requiredWith:  attr.RequiredWith,
+		})
+
+		if attr.NestedType == nil || !aval.IsKnown() || aval.IsNull() {
+			continue
+		}
+		nestedAttrs := attr.NestedType.Attributes
+		if attr.NestedType.Nesting == schema.SchemaObjectNestingModeSingle {
+			v.attributes(apath, key+".", aval, nestedAttrs)
+			continue
+		}
+		v.elements(apath, key, aval, func(epath cty.Path, eprefix string, eval cty.Value) {
+			v.attributes(epath, eprefix, eval, nestedAttrs)
+		})
+	}
+}
+
+// elements calls the function for each known and non-null element of the collection value, with the path and the
+// SDKv2 schema key prefix of the element.
+func (v *valueValidator) elements(path cty.Path, key string, val cty.Value, f func(path cty.Path, prefix string, val cty.Value)) {
+	if !val.CanIterateElements() {
+		return
+	}
+	i := 0
+	for it := val.ElementIterator(); it.Next(); i++ {
+		k, ev := it.Element()
+		if !ev.IsKnown() || ev.IsNull() {
+			continue
+		}
+		var (
+			epath cty.Path
+			seg   string
+		)
+		switch {
+		case val.Type().IsSetType():
+			epath = path.Copy().Index(ev)
+			seg = strconv.Itoa(i)
+		case k.Type() == cty.String:
+			epath = path.Copy().Index(k)
+			seg = k.AsString()
+		default:
+			epath = path.Copy().Index(k)
+			seg = strconv.Itoa(i)
+		}
+		f(epath, key+"."+seg+".", ev)
+	}
+}
+
+func (v *valueValidator) constraints(path cty.Path, key string, keySet bool, c constraints) {
+	for _, violation := range c.check(key, keySet, v.present) {
+		v.errs = append(v.errs, path.NewErrorf("%s", violation.detail))
+	}
+}
+
+// present tells whether the SDKv2 schema key (e.g. "block.0.attr") is set in the root value.
+// The set elements are indexed by their iteration order.
+func (v *valueValidator) present(key string) bool {
+	cur := v.root
+	for _, seg := range strings.Split(key, ".") {
+		if !cur.IsKnown() {
+			return true
+		}
+		if cur.IsNull() {
+			return false
+		}
+		ty := cur.Type()
+		switch {
+		case ty.IsObjectType():
+			if !ty.HasAttribute(seg) {
+				return false
+			}
+			cur = cur.GetAttr(seg)
+		case ty.IsMapType():
+			if !cur.HasIndex(cty.StringVal(seg)).True() {
+				return false
+			}
+			cur = cur.Index(cty.StringVal(seg))
+		case ty.IsListType(), ty.IsSetType(), ty.IsTupleType():
+			idx, err := strconv.Atoi(seg)
+			if err != nil || idx < 0 {
+				return false
+			}
+			found := false
+			i := 0
+			for it := cur.ElementIterator(); it.Next(); i++ {
+				if i == idx {
+					_, cur = it.Element()
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return present(cur)
+}
+
+// present tells whether the value is regarded as set.
+func present(val cty.Value) bool {
+	if !val.IsKnown() {
+		return true
+	}
+	if val.IsNull() {
+		return false
+	}
+	if val.Type().IsCollectionType() {
+		return val.LengthInt() != 0
+	}
+	return true
+}
@@ -0,0 +1,285 @@
+package validate_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/magodo/tfpluginschema/schema"
+	"github.com/magodo/tfpluginschema/validate"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func testValue(overrides map[string]cty.Value) cty.Value {
+	attrs := map[string]cty.Value{
+		"name":     cty.StringVal("foo"),
+		"id":       cty.NullVal(cty.String),
+		"size":     cty.NumberIntVal(1),
+		"sku":      cty.NullVal(cty.String),
+		"username": cty.NullVal(cty.String),
+		"password": cty.NullVal(cty.String),
+		"tags":     cty.NullVal(cty.Map(cty.String)),
+		"zone":     cty.NullVal(cty.String),
+		"network": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"zones":     cty.NullVal(cty.List(cty.String)),
+				"subnet_id": cty.StringVal("x"),
+				"vnet_id":   cty.NullVal(cty.String),
+			}),
+		}),
+		"rule": cty.SetValEmpty(cty.Object(map[string]cty.Type{"priority": cty.Number})),
+	}
+	for k, v := range overrides {
+		attrs[k] = v
+	}
+	return cty.ObjectVal(attrs)
+}
+
+var networkType = cty.Object(map[string]cty.Type{
+	"zones":     cty.List(cty.String),
+	"subnet_id": cty.String,
+	"vnet_id":   cty.String,
+})
+
+func TestValue(t *testing.T) {
+	cases := []struct {
+		name string
+		val  cty.Value
+		sch  *schema.Schema
+		// expect is a list of "<path>: <message>" of the errors.
+		expect []string
+	}{
+		{
+			name: "valid",
+			val: testValue(map[string]cty.Value{
+				"username": cty.StringVal("admin"),
+				"password": cty.StringVal("secret"),
+				"rule": cty.SetVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{"priority": cty.NumberIntVal(1)}),
+				}),
+			}),
+		},
+		{
+			name: "unknown",
+			val: testValue(map[string]cty.Value{
+				"name":    cty.UnknownVal(cty.String),
+				"size":    cty.UnknownVal(cty.Number),
+				"sku":     cty.StringVal("small"),
+				"network": cty.UnknownVal(cty.List(networkType)),
+			}),
+			expect: []string{
+				".size: \"size\": only one of `size,sku` can be specified, but `size,sku` were specified.",
+				".sku: \"sku\": only one of `size,sku` can be specified, but `size,sku` were specified.",
+			},
+		},
+		{
+			name: "nonconforming type",
+			val: testValue(map[string]cty.Value{
+				"size": cty.StringVal("large"),
+				"foo":  cty.StringVal("bar"),
+			}),
+			expect: []string{
+				": unsupported attribute \"foo\"",
+				".size: number required, but received string",
+			},
+		},
+		{
+			name:   "null",
+			val:    cty.NullVal(cty.EmptyObject),
+			sch:    &schema.Schema{Block: &schema.SchemaBlock{}},
+			expect: []string{": value is null"},
+		},
+		{
+			name: "required and items",
+			val: testValue(map[string]cty.Value{
+				"name":    cty.NullVal(cty.String),
+				"network": cty.ListValEmpty(networkType),
+				"rule": cty.SetVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{"priority": cty.NullVal(cty.Number)}),
+				}),
+			}),
+			expect: []string{
+				`.name: attribute "name" is required`,
+				`.network: at least 1 "network" blocks are required, got 0`,
+				`.rule[cty.ObjectVal(map[string]cty.Value{"priority":cty.NullVal(cty.Number)})].priority: attribute "priority" is required`,
+			},
+		},
+		{
+			name: "too many blocks",
+			val: testValue(map[string]cty.Value{
+				"network": cty.ListVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{
+						"zones":     cty.NullVal(cty.List(cty.String)),
+						"subnet_id": cty.StringVal("x"),
+						"vnet_id":   cty.NullVal(cty.String),
+					}),
+					cty.ObjectVal(map[string]cty.Value{
+						"zones":     cty.NullVal(cty.List(cty.String)),
+						"subnet_id": cty.StringVal("y"),
+						"vnet_id":   cty.NullVal(cty.String),
+					}),
+				}),
+			}),
+			expect: []string{
+				`.network: no more than 1 "network" blocks are allowed, got 2`,
+			},
+		},
+		{
+			name: "exactly one of none",
+			val: testValue(map[string]cty.Value{
+				"size": cty.NullVal(cty.Number),
+			}),
+			expect: []string{
+				".size: \"size\": one of `size,sku` must be specified",
+				".sku: \"sku\": one of `size,sku` must be specified",
+			},
+		},
+		{
+			name: "at least one of",
+			val: testValue(map[string]cty.Value{
+				"network": cty.ListVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{
+						"zones":     cty.NullVal(cty.List(cty.String)),
+						"subnet_id": cty.NullVal(cty.String),
+						"vnet_id":   cty.NullVal(cty.String),
+					}),
+				}),
+			}),
+			expect: []string{
+				".network[0].subnet_id: \"network.0.subnet_id\": one of `network.0.subnet_id,network.0.vnet_id` must be specified",
+				".network[0].vnet_id: \"network.0.vnet_id\": one of `network.0.subnet_id,network.0.vnet_id` must be specified",
+			},
+		},
+		{
+			name: "required with and conflicts with",
+			val: testValue(map[string]cty.Value{
+				"username": cty.StringVal("admin"),
+				"zone":     cty.StringVal("1"),
+				"network": cty.ListVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{
+						"zones":     cty.ListVal([]cty.Value{cty.StringVal("1")}),
+						"subnet_id": cty.StringVal("x"),
+						"vnet_id":   cty.NullVal(cty.String),
+					}),
+				}),
+			}),
+			expect: []string{
+				".username: \"username\": all of `password,username` must be specified",
+				".zone: \"zone\": conflicts with network.0.zones",
+			},
+		},
+		{
+			name: "empty collection is not set",
+			val: testValue(map[string]cty.Value{
+				"zone": cty.StringVal("1"),
+				"network": cty.ListVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{
+						"zones":     cty.ListValEmpty(cty.String),
+						"subnet_id": cty.StringVal("x"),
+						"vnet_id":   cty.NullVal(cty.String),
+					}),
+				}),
+			}),
+		},
+		{
+			name: "marked",
+			val: testValue(map[string]cty.Value{
+				"username": cty.StringVal("admin").Mark("sensitive"),
+				"zone":     cty.StringVal("1"),
+				"tags":     cty.MapVal(map[string]cty.Value{"a": cty.StringVal("b")}).Mark("sensitive"),
+				"network": cty.ListVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{
+						"zones":     cty.ListVal([]cty.Value{cty.StringVal("1")}).Mark("sensitive"),
+						"subnet_id": cty.StringVal("x"),
+						"vnet_id":   cty.NullVal(cty.String),
+					}),
+				}).Mark("sensitive"),
+				"rule": cty.SetVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{"priority": cty.NumberIntVal(1).Mark("sensitive")}),
+				}),
+			}).Mark("sensitive"),
+			expect: []string{
+				".username: \"username\": all of `password,username` must be specified",
+				".zone: \"zone\": conflicts with network.0.zones",
+			},
+		},
+		{
+			name: "nested attribute",
+			sch: &schema.Schema{
+				Block: &schema.SchemaBlock{
+					Attributes: []*schema.SchemaAttribute{
+						{
+							Name:     "rules",
+							Optional: true,
+							NestedType: &schema.SchemaObject{
+								Nesting: schema.SchemaObjectNestingModeList,
+								Attributes: []*schema.SchemaAttribute{
+									{Name: "name", Type: ToPtr(cty.String), Required: true},
+									{Name: "a", Type: ToPtr(cty.String), Optional: true, ConflictsWith: []string{"rules.0.b"}},
+									{Name: "b", Type: ToPtr(cty.String), Optional: true},
+								},
+							},
+						},
+					},
+				},
+			},
+			val: cty.ObjectVal(map[string]cty.Value{
+				"rules": cty.ListVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{
+						"name": cty.NullVal(cty.String),
+						"a":    cty.StringVal("a"),
+						"b":    cty.StringVal("b"),
+					}),
+				}),
+			}),
+			expect: []string{
+				`.rules[0].name: attribute "name" is required`,
+				".rules[0].a: \"rules.0.a\": conflicts with rules.0.b",
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			sch := tt.sch
+			if sch == nil {
+				sch = testSchema
+			}
+			err := validate.Value(tt.val, sch)
+			if len(tt.expect) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			var got []string
+			errs := []error{err}
+			if joined, ok := err.(interface{ Unwrap() []error }); ok {
+				errs = joined.Unwrap()
+			}
+			for _, err := range errs {
+				var pathErr cty.PathError
+				require.True(t, errors.As(err, &pathErr), "%v is not a cty.PathError", err)
+				got = append(got, fmt.Sprintf("%s: %s", formatPath(pathErr.Path), pathErr.Error()))
+			}
+			require.ElementsMatch(t, tt.expect, got)
+		})
+	}
+}
+
+func formatPath(path cty.Path) string {
+	var out string
+	for _, step := range path {
+		switch step := step.(type) {
+		case cty.GetAttrStep:
+			out += "." + step.Name
+		case cty.IndexStep:
+			if step.Key.Type() == cty.Number {
+				out += "[" + step.Key.AsBigFloat().Text('f', -1) + "]"
+			} else {
+				out += "[" + step.Key.GoString() + "]"
+			}
+		}
+	}
+	return out
+}